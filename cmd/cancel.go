@@ -0,0 +1,117 @@
+/*
+Copyright © 2021
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// cleanupTimeout bounds the compensating actions, which run once the context of the command is done.
+const cleanupTimeout = time.Minute
+
+// compensation undoes a step of a command, for example by deleting the cluster it created.
+type compensation struct {
+	description string
+	undo        func(ctx context.Context) error
+}
+
+// notifyContext returns a context canceled on SIGINT or SIGTERM. The signal is remembered,
+// see interruptSignal. Only the first signal is caught: a second Ctrl-C, for example while
+// rolling back, terminates the process. The returned function stops listening to the signals.
+func (cli *CLI) notifyContext(parent context.Context) (context.Context, func()) {
+	ctx, cancel := context.WithCancel(parent)
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+
+	done := make(chan struct{})
+	go func() {
+		select {
+		case sig := <-signals:
+			signal.Stop(signals)
+			cli.mu.Lock()
+			cli.signal = sig
+			cli.mu.Unlock()
+			cancel()
+		case <-done:
+		}
+	}()
+
+	return ctx, func() {
+		signal.Stop(signals)
+		close(done)
+		cancel()
+	}
+}
+
+// interruptSignal returns the signal that canceled the command, nil when there was none.
+func (cli *CLI) interruptSignal() os.Signal {
+	cli.mu.Lock()
+	defer cli.mu.Unlock()
+	return cli.signal
+}
+
+// onCancel registers how to undo a step of the running command when it gets canceled,
+// for example deleting a cluster it created before waiting for it.
+func (cli *CLI) onCancel(description string, undo func(ctx context.Context) error) {
+	cli.mu.Lock()
+	defer cli.mu.Unlock()
+	cli.compensations = append(cli.compensations, compensation{description: description, undo: undo})
+}
+
+// compensate runs the compensating actions of a canceled command, the last registered first,
+// when --rollback-on-cancel is set. Otherwise it tells what was left behind.
+func (cli *CLI) compensate() {
+	cli.mu.Lock()
+	compensations := cli.compensations
+	cli.compensations = nil
+	cli.mu.Unlock()
+
+	if len(compensations) == 0 {
+		return
+	}
+
+	if !cli.rollbackOnCancel {
+		fmt.Fprintln(cli.ErrOut, "Canceled, left behind:")
+		for _, c := range compensations {
+			fmt.Fprintln(cli.ErrOut, "  "+c.description)
+		}
+		fmt.Fprintln(cli.ErrOut, "Use --rollback-on-cancel to undo them on cancellation.")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), cleanupTimeout)
+	defer cancel()
+	for i := len(compensations) - 1; i >= 0; i-- {
+		c := compensations[i]
+		fmt.Fprintln(cli.ErrOut, "Rolling back: "+c.description)
+		if err := c.undo(ctx); err != nil {
+			fmt.Fprintf(cli.ErrOut, "Failed to roll back %s: %v\n", c.description, err)
+		}
+	}
+}
+
+// signalExitCode is the conventional exit code of a process terminated by the signal.
+func signalExitCode(sig os.Signal) int {
+	if number, ok := sig.(syscall.Signal); ok {
+		return 128 + int(number)
+	}
+	return 1
+}
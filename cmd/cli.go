@@ -0,0 +1,100 @@
+/*
+Copyright © 2021
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"context"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	cc "github.com/camunda-community-hub/camunda-cloud-go-client/pkg/cc/client"
+	"github.com/spf13/viper"
+)
+
+// IOStreams are the streams the commands read from and print to.
+type IOStreams struct {
+	In     io.Reader
+	Out    io.Writer
+	ErrOut io.Writer
+}
+
+// SystemIOStreams returns the standard input, output and error of the process.
+func SystemIOStreams() IOStreams {
+	return IOStreams{In: os.Stdin, Out: os.Stdout, ErrOut: os.Stderr}
+}
+
+// CLI is what the commands share. Zero fields get their defaults in NewRootCmd,
+// so tests only set what they need to replace.
+type CLI struct {
+	IOStreams
+
+	// Client is logged in before every command that talks to Camunda Cloud, a *cc.CCClient by default.
+	// Other implementations, for example the mock of the mocks package, serve all the commands but
+	// doctor, which checks the endpoints and token of a *cc.CCClient.
+	Client cc.CCAPI
+
+	// Config holds the contexts. When nil, the config file is read into a new one.
+	Config *viper.Viper
+
+	// Getenv looks the CC_* environment variables up, os.Getenv by default.
+	Getenv func(key string) string
+
+	// Now is the clock, time.Now by default.
+	Now func() time.Time
+
+	// BaseContext is the context of the commands, context.Background by default.
+	// Run also cancels it on SIGINT and SIGTERM.
+	BaseContext context.Context
+
+	// contextName is the context selected with --context.
+	contextName string
+
+	// context is the context the client logged in with.
+	context Context
+
+	// rollbackOnCancel is set by --rollback-on-cancel.
+	rollbackOnCancel bool
+
+	mu            sync.Mutex
+	signal        os.Signal
+	compensations []compensation
+}
+
+func (cli *CLI) setDefaults() {
+	if cli.In == nil {
+		cli.In = os.Stdin
+	}
+	if cli.Out == nil {
+		cli.Out = os.Stdout
+	}
+	if cli.ErrOut == nil {
+		cli.ErrOut = os.Stderr
+	}
+	if cli.Client == nil {
+		cli.Client = &cc.CCClient{}
+	}
+	if cli.Getenv == nil {
+		cli.Getenv = os.Getenv
+	}
+	if cli.Now == nil {
+		cli.Now = time.Now
+	}
+	if cli.BaseContext == nil {
+		cli.BaseContext = context.Background()
+	}
+}
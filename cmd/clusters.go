@@ -16,22 +16,18 @@ limitations under the License.
 package cmd
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
 
 	cc "github.com/camunda-community-hub/camunda-cloud-go-client/pkg/cc/client"
 	"github.com/spf13/cobra"
 )
 
-var (
-	name       string
-	id         string
-	channel    string
-	generation string
-	region     string
-	plan       string
-)
-
 var (
 	deleteExample = `
 
@@ -42,6 +38,12 @@ var (
 
   # List all clusters
   cc-ctl clusters get --all
+
+  # List all clusters including their status and endpoints
+  cc-ctl clusters get --all --status
+
+  # List the ready development clusters whose name starts with ci-, oldest first
+  cc-ctl clusters get --all --name-prefix=ci- --plan=Development --ready --sort-by=created
    
   # Get cluster by name
   cc-ctl clusters get --name=<cluster_name> (If your cluster have a composite name, use: --name='<cluster name>')
@@ -52,171 +54,389 @@ var (
 
   # Create cluster with default configuration
   cc-ctl clusters create --default --name=<cluster_name> (If your cluster have a composite name, use: --name='<cluster name>')
+
+  # Create cluster and wait for it to be healthy, deleting it when interrupted
+  cc-ctl clusters create --default --name=<cluster_name> --wait --rollback-on-cancel
+
+  # Create cluster from a template of the config file, see cc-ctl templates
+  cc-ctl clusters create --template=staging --name=<cluster_name>
+
+  # Create cluster unless it already exists with the same configuration
+  cc-ctl clusters create --default --name=<cluster_name> --if-not-exists
  
   # Crate cluster with custom configuration
   cc-ctl clusters create 
     --name=<cluster_name> (If your cluster have a composite name, use: --name='<cluster name>'
-	--channel=<channel_id>
-	--generation=<generation_id>
-	--region=<region_id>
-	--plan=<plan_type_id>`
+	--channel=<channel_name_or_id>
+	--generation=<generation_name_or_id>
+	--region=<region_name_or_id>
+	--plan=<plan_type_name_or_id>`
 )
 
-var clusterCmd = &cobra.Command{
-	Use:   "clusters [options]",
-	Short: "Manage your cluster's resources on Camunda Cloud",
-	Long:  "Used together [OPTIONS] like get, create, delete for manage your resources on Camunda Cloud. For example:" + getExample + createExample + deleteExample,
+// clusterGetFlags are the flags of the clusters get command.
+type clusterGetFlags struct {
+	name          string
+	listOptions   cc.ListOptions
+	namePrefix    string
+	nameGlob      string
+	nameRegex     string
+	createdBefore string
+	createdAfter  string
+	sortBy        string
+	output        string
 }
 
-var getClusterCmd = &cobra.Command{
-	Use:   "get",
-	Short: "Get clusters",
-	Long:  "Used together with clusters command, to get your clusters on Camunda Cloud. For example:" + getExample,
-	Run: func(cmd *cobra.Command, args []string) {
-		all, _ := cmd.Flags().GetBool("all")
-		params, _ := cmd.Flags().GetBool("params")
-
-		if name != "" && params && all {
-			fmt.Println("Error: --all and --name and --params cannot be specified together")
-			return
-		}
+// CreateClustersCmd represents the clusters command
+func CreateClustersCmd(cli *CLI) *cobra.Command {
+	clusterCmd := &cobra.Command{
+		Use:   "clusters [options]",
+		Short: "Manage your cluster's resources on Camunda Cloud",
+		Long:  "Used together [OPTIONS] like get, create, delete for manage your resources on Camunda Cloud. For example:" + getExample + createExample + deleteExample,
+	}
+
+	clusterCmd.AddCommand(CreateClustersGetCmd(cli))
+	clusterCmd.AddCommand(CreateClustersCreateCmd(cli))
+	clusterCmd.AddCommand(CreateClustersDeleteCmd(cli))
+	clusterCmd.AddCommand(CreateClustersGcCmd(cli))
+	clusterCmd.AddCommand(CreateClustersCloneCmd(cli))
+	clusterCmd.AddCommand(CreateClustersOutdatedCmd(cli))
+
+	return clusterCmd
+}
 
-		if name != "" && params {
-			fmt.Println("Error: --name and --params cannot be specified together")
-			return
-		}
+func CreateClustersGetCmd(cli *CLI) *cobra.Command {
+	flags := &clusterGetFlags{}
+
+	getClusterCmd := &cobra.Command{
+		Use:   "get",
+		Short: "Get clusters",
+		Long:  "Used together with clusters command, to get your clusters on Camunda Cloud. For example:" + getExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			all, _ := cmd.Flags().GetBool("all")
+			params, _ := cmd.Flags().GetBool("params")
+			status, _ := cmd.Flags().GetBool("status")
+			name := flags.name
+
+			if name != "" && params && all {
+				return fmt.Errorf("--all and --name and --params cannot be specified together")
+			}
 
-		if name != "" && all {
-			fmt.Println("Error: --all and --name cannot be specified together")
-			return
-		}
+			if name != "" && params {
+				return fmt.Errorf("--name and --params cannot be specified together")
+			}
 
-		if all && params {
-			fmt.Println("Error: --all and --params cannot be specified together")
-			return
-		}
+			if name != "" && all {
+				return fmt.Errorf("--all and --name cannot be specified together")
+			}
 
-		if name != "" {
-			cluster, _ := client.GetClusterByName(name)
-			showCluster(cluster)
-			return
-		}
+			if all && params {
+				return fmt.Errorf("--all and --params cannot be specified together")
+			}
 
-		if all {
-			clusters, _ := client.GetClusters()
-			showClusters(clusters)
-			return
-		}
+			if flags.output != "json" && flags.output != "table" {
+				return fmt.Errorf("--output should be json or table: %s", flags.output)
+			}
 
-		if params {
-			params, _ := client.GetClusterParams()
-			showParams(*params)
-		}
+			if name != "" {
+				cluster, err := cli.Client.GetClusterByNameWithContext(cmd.Context(), name)
+				if err != nil {
+					return err
+				}
+				return showClusters(cli.Out, flags.output, []cc.Cluster{cluster}, false)
+			}
 
-	},
-}
+			if all {
+				opts, err := flags.clusterListOptions(cmd)
+				if err != nil {
+					return err
+				}
 
-var createClusterCmd = &cobra.Command{
-	Use:   "create",
-	Short: "Create cluster",
-	Long:  "Used together clusters command, to create your clusters on Camunda Cloud. For example:" + createExample,
-	PreRun: func(cmd *cobra.Command, args []string) {
-		def, _ := cmd.Flags().GetBool("default")
-
-		if !def {
-			cmd.MarkFlagRequired("channel")
-			cmd.MarkFlagRequired("generation")
-			cmd.MarkFlagRequired("region")
-			cmd.MarkFlagRequired("plan")
-		}
-	},
-	Run: func(cmd *cobra.Command, args []string) {
+				var clusters []cc.Cluster
+				if status {
+					clusters, err = cli.Client.GetClustersWithStatus(cmd.Context())
+					if err == nil {
+						clusters, err = cc.FilterClusters(clusters, opts)
+					}
+				} else {
+					clusters, err = cli.Client.ListClusters(cmd.Context(), opts)
+				}
+				if err != nil {
+					return err
+				}
+				return showClusters(cli.Out, flags.output, clusters, true)
+			}
 
-		def, _ := cmd.Flags().GetBool("default")
+			if params {
+				params, err := cli.Client.GetClusterParamsWithContext(cmd.Context())
+				if err != nil {
+					return err
+				}
+				return showJSON(cli.Out, *params)
+			}
 
-		if !def {
-			clusterID, err := client.CreateClusterCustomConfig(cc.NewClusterCreationParams(
-				name, channel, generation, region, plan,
-			))
+			return nil
+		},
+	}
 
-			if err != nil {
-				fmt.Println(err.Error())
-			} else {
-				fmt.Println("Cluster create successfully. Cluster id:", clusterID)
+	getClusterCmd.Flags().BoolP("all", "a", false, "Get all clusters: cc-ctl get --all")
+	getClusterCmd.Flags().BoolP("params", "p", false, "Get params to create a cluster: cc-ctl get --params")
+	getClusterCmd.Flags().StringVarP(&flags.name, "name", "n", "", "cc-ctl clusters get --name='<cluster_name>'")
+	getClusterCmd.Flags().BoolP("status", "s", false, "Include the status of each cluster: cc-ctl get --all --status")
+	getClusterCmd.Flags().StringVar(&flags.namePrefix, "name-prefix", "", "Only list clusters whose name starts with this prefix")
+	getClusterCmd.Flags().StringVar(&flags.nameGlob, "name-glob", "", "Only list clusters whose name matches this glob pattern")
+	getClusterCmd.Flags().StringVar(&flags.nameRegex, "name-regex", "", "Only list clusters whose name matches this regular expression")
+	getClusterCmd.Flags().StringVar(&flags.listOptions.Channel, "channel", "", "Only list clusters on this channel (id or name)")
+	getClusterCmd.Flags().StringVar(&flags.listOptions.Generation, "generation", "", "Only list clusters on this generation (id or name)")
+	getClusterCmd.Flags().StringVar(&flags.listOptions.Plan, "plan", "", "Only list clusters with this plan type (id or name)")
+	getClusterCmd.Flags().StringVar(&flags.listOptions.Region, "region", "", "Only list clusters in this region (id or name)")
+	getClusterCmd.Flags().Bool("ready", false, "Only list ready clusters (--ready=false for clusters that are not ready)")
+	getClusterCmd.Flags().StringVar(&flags.createdBefore, "created-before", "", "Only list clusters created before this time (RFC3339 or YYYY-MM-DD)")
+	getClusterCmd.Flags().StringVar(&flags.createdAfter, "created-after", "", "Only list clusters created after this time (RFC3339 or YYYY-MM-DD)")
+	getClusterCmd.Flags().StringVar(&flags.sortBy, "sort-by", "", "Sort clusters by name, created, generation, plan or region")
+	getClusterCmd.Flags().BoolVar(&flags.listOptions.Descending, "desc", false, "Sort in descending order")
+	getClusterCmd.Flags().IntVar(&flags.listOptions.Limit, "limit", 0, "Maximum number of clusters to list")
+	getClusterCmd.Flags().StringVarP(&flags.output, "output", "o", "json", "Output format: json or table")
+
+	return getClusterCmd
+}
+
+func CreateClustersCreateCmd(cli *CLI) *cobra.Command {
+	var name, channel, generation, region, plan, template string
+	var wait, ifNotExists bool
+	var waitTimeout, pollInterval time.Duration
+
+	createClusterCmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create cluster",
+		Long:  "Used together clusters command, to create your clusters on Camunda Cloud. For example:" + createExample,
+		PreRun: func(cmd *cobra.Command, args []string) {
+			def, _ := cmd.Flags().GetBool("default")
+
+			if !def && template == "" {
+				cmd.MarkFlagRequired("channel")
+				cmd.MarkFlagRequired("generation")
+				cmd.MarkFlagRequired("region")
+				cmd.MarkFlagRequired("plan")
 			}
-		} else {
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
 
-			if name != "" {
+			def, _ := cmd.Flags().GetBool("default")
 
-				client.GetClusterParams()
-				clusterID, err := client.CreateClusterDefault(name)
+			if def && template != "" {
+				return fmt.Errorf("--default and --template cannot be specified together")
+			}
 
+			spec := cc.ClusterSpec{Name: name}
+			if template != "" {
+				t, err := loadTemplate(cli.Config, template)
 				if err != nil {
-					fmt.Println(err.Error())
-				} else {
-					fmt.Println("Cluster created successfully. Cluster id:", clusterID)
+					return err
 				}
+				spec = t.spec(name)
+			}
+			if !def {
+				// Flags override the fields of the template.
+				overrideFlag(cmd, "plan", plan, &spec.Plan)
+				overrideFlag(cmd, "channel", channel, &spec.Channel)
+				overrideFlag(cmd, "generation", generation, &spec.Generation)
+				overrideFlag(cmd, "region", region, &spec.Region)
 			}
-		}
-	},
-}
 
-var deleteClusterCmd = &cobra.Command{
-	Use:   "delete",
-	Short: "Delete cluster",
-	Long:  "Used together with clusters command, to delete your clusters on Camunda Cloud. For example:" + deleteExample,
-	Run: func(cmd *cobra.Command, args []string) {
+			var clusterID, created string
+			var err error
+			if ifNotExists {
+				var cluster cc.Cluster
+				var isNew bool
+				cluster, isNew, err = cli.Client.EnsureCluster(cmd.Context(), spec)
+				clusterID = cluster.ID
+				created = "Cluster created successfully. Cluster id:"
+				if err == nil && !isNew {
+					fmt.Fprintln(cli.Out, "Cluster already exists. Cluster id:", clusterID)
+					created = ""
+				}
+			} else if !def {
+				clusterID, err = cli.Client.CreateClusterFromSpec(cmd.Context(), spec)
+				created = "Cluster created successfully. Cluster id:"
+			} else {
+				clusterID, err = cli.Client.CreateClusterDefaultWithContext(cmd.Context(), name)
+				created = "Cluster created successfully. Cluster id:"
+			}
 
-		if id != "" {
-			success, _ := client.DeleteCluster(id)
+			if err != nil {
+				return err
+			}
+			if created != "" {
+				cli.onCancel(fmt.Sprintf("cluster %s (%s)", name, clusterID), func(ctx context.Context) error {
+					_, err := cli.Client.DeleteClusterWithContext(ctx, clusterID)
+					return err
+				})
+				fmt.Fprintln(cli.Out, created, clusterID)
+			}
 
-			if success {
-				fmt.Println("Cluster deleted successfully")
-			} else {
-				fmt.Println("Error: We can't delete your cluster")
+			if !wait {
+				return nil
 			}
-		}
-	},
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), waitTimeout)
+			defer cancel()
+			if _, err := cli.Client.WaitForClusterHealthy(ctx, clusterID, pollInterval); err != nil {
+				if errors.Is(err, context.DeadlineExceeded) && cmd.Context().Err() == nil {
+					return fmt.Errorf("cluster %s was not healthy after %s", clusterID, waitTimeout)
+				}
+				return err
+			}
+			fmt.Fprintln(cli.Out, "Cluster is healthy")
+			return nil
+		},
+	}
+
+	createClusterCmd.Flags().BoolP("default", "d", false, "cc-ctl clusters create --default=(true|false)")
+	createClusterCmd.Flags().StringVarP(&name, "name", "n", "", "Cluster's name")
+	createClusterCmd.Flags().StringVarP(&channel, "channel", "c", "", "Cluster's channel name or id")
+	createClusterCmd.Flags().StringVarP(&generation, "generation", "g", "", "Cluster's generation name or id")
+	createClusterCmd.Flags().StringVarP(&region, "region", "r", "", "Cluster's region name or id")
+	createClusterCmd.Flags().StringVarP(&plan, "plan", "p", "", "Cluster's plan type name or id")
+	createClusterCmd.Flags().StringVarP(&template, "template", "t", "", "Template of the config file to take the plan, channel, generation and region from, see cc-ctl templates")
+	createClusterCmd.Flags().BoolVarP(&wait, "wait", "w", false, "Wait for Zeebe to be healthy, see --rollback-on-cancel to delete the cluster when interrupted")
+	createClusterCmd.Flags().BoolVar(&ifNotExists, "if-not-exists", false, "Reuse the cluster with this name when its plan, channel, generation and region match, fail when they do not")
+	createClusterCmd.Flags().DurationVar(&waitTimeout, "wait-timeout", 20*time.Minute, "Maximum time to wait for the cluster to be healthy")
+	createClusterCmd.Flags().DurationVar(&pollInterval, "poll-interval", cc.DefaultPollInterval, "Time between two checks of the cluster status")
+	createClusterCmd.MarkFlagRequired("name")
+
+	return createClusterCmd
 }
 
-func init() {
+func CreateClustersDeleteCmd(cli *CLI) *cobra.Command {
+	var id string
 
-	clusterCmd.AddCommand(getClusterCmd)
-	clusterCmd.AddCommand(createClusterCmd)
-	clusterCmd.AddCommand(deleteClusterCmd)
-	rootCmd.AddCommand(clusterCmd)
+	deleteClusterCmd := &cobra.Command{
+		Use:   "delete",
+		Short: "Delete cluster",
+		Long:  "Used together with clusters command, to delete your clusters on Camunda Cloud. For example:" + deleteExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
 
-	// get cmd
-	getClusterCmd.Flags().BoolP("all", "a", false, "Get all clusters: cc-ctl get --all")
-	getClusterCmd.Flags().BoolP("params", "p", false, "Get params to create a cluster: cc-ctl get --params")
-	getClusterCmd.Flags().StringVarP(&name, "name", "n", "", "cc-ctl clusters get --name='<cluster_name>'")
+			success, err := cli.Client.DeleteClusterWithContext(cmd.Context(), id)
+
+			if !success {
+				if err != nil {
+					return fmt.Errorf("We can't delete your cluster: %v", err)
+				}
+				return fmt.Errorf("We can't delete your cluster")
+			}
+			fmt.Fprintln(cli.Out, "Cluster deleted successfully")
+			return nil
+		},
+	}
 
-	// delete cmd
 	deleteClusterCmd.PersistentFlags().StringVarP(&id, "id", "i", "", "cc-ctl clusters delete --id=<cluster_id>")
-	deleteClusterCmd.MarkFlagRequired("id")
+	deleteClusterCmd.MarkPersistentFlagRequired("id")
 
-	// create cmd
-	createClusterCmd.Flags().BoolP("default", "d", false, "cc-ctl clusters create --default=(true|false)")
-	createClusterCmd.Flags().StringVarP(&name, "name", "n", "", "Cluster's name")
-	createClusterCmd.Flags().StringVarP(&channel, "channel", "c", "", "Cluster's channel id")
-	createClusterCmd.Flags().StringVarP(&generation, "generation", "g", "", "Cluster's generation id")
-	createClusterCmd.Flags().StringVarP(&region, "region", "r", "", "Cluster's region id")
-	createClusterCmd.Flags().StringVarP(&plan, "plan", "p", "", "Cluster's plan type id")
-	createClusterCmd.MarkFlagRequired("name")
+	return deleteClusterCmd
 }
 
-func showCluster(cluster cc.Cluster) {
-	data, _ := json.MarshalIndent(cluster, "", "  ")
-	fmt.Println(string(data))
+func showJSON(out io.Writer, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(out, string(data))
+	return nil
 }
 
-func showClusters(clusters []cc.Cluster) {
-	data, _ := json.MarshalIndent(clusters, "", "  ")
-	fmt.Println(string(data))
+// showClusters prints the clusters in the output format. A single cluster is printed
+// as a JSON object rather than a list unless asList is set.
+func showClusters(out io.Writer, output string, clusters []cc.Cluster, asList bool) error {
+	if output == "table" {
+		w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+		fmt.Fprintln(w, "ID\tNAME\tPLAN\tCHANNEL\tGENERATION\tREGION\tSTATUS\tCREATED")
+		for _, cluster := range clusters {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+				cluster.ID,
+				cluster.Name,
+				cluster.ClusterPlantType.Name,
+				cluster.Channel.Name,
+				cluster.Generation.Name,
+				cluster.K8sContext.Name,
+				valueOrDash(string(cluster.Health())),
+				cluster.Created.UTC().Format(time.RFC3339))
+		}
+		return w.Flush()
+	}
+
+	if !asList && len(clusters) == 1 {
+		return showJSON(out, clusters[0])
+	}
+	return showJSON(out, clusters)
+}
+
+// overrideFlag replaces the field with the value of the flag when it was set.
+func overrideFlag(cmd *cobra.Command, flag string, value string, field *string) {
+	if cmd.Flags().Changed(flag) {
+		*field = value
+	}
+}
+
+func valueOrDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
 }
 
-func showParams(params cc.ClusterParams) {
-	data, _ := json.MarshalIndent(params, "", "  ")
-	fmt.Println(string(data))
+// clusterListOptions builds the list options from the filter flags of the get command.
+func (flags *clusterGetFlags) clusterListOptions(cmd *cobra.Command) (cc.ListOptions, error) {
+	opts := flags.listOptions
+	opts.SortBy = cc.ClusterSortField(flags.sortBy)
+
+	var err error
+	if opts.Name, opts.NameMatch, err = nameFilter(flags.namePrefix, flags.nameGlob, flags.nameRegex); err != nil {
+		return opts, err
+	}
+
+	if cmd.Flags().Changed("ready") {
+		ready, _ := cmd.Flags().GetBool("ready")
+		opts.Ready = &ready
+	}
+
+	if opts.CreatedBefore, err = parseTimeFlag("created-before", flags.createdBefore); err != nil {
+		return opts, err
+	}
+	if opts.CreatedAfter, err = parseTimeFlag("created-after", flags.createdAfter); err != nil {
+		return opts, err
+	}
+
+	return opts, nil
 }
 
+// nameFilter turns the --name-prefix, --name-glob and --name-regex flags into a name filter.
+func nameFilter(prefix string, glob string, regex string) (string, cc.NameMatch, error) {
+	name, match := "", cc.NameMatch("")
+	nameFilters := 0
+	for m, value := range map[cc.NameMatch]string{
+		cc.NameMatchPrefix: prefix,
+		cc.NameMatchGlob:   glob,
+		cc.NameMatchRegex:  regex,
+	} {
+		if value != "" {
+			name, match = value, m
+			nameFilters++
+		}
+	}
+	if nameFilters > 1 {
+		return "", "", fmt.Errorf("--name-prefix, --name-glob and --name-regex cannot be specified together")
+	}
+	return name, match, nil
+}
+
+func parseTimeFlag(flag string, value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("--%s should be a RFC3339 time or a YYYY-MM-DD date: %s", flag, value)
+}
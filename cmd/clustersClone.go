@@ -0,0 +1,128 @@
+/*
+Copyright © 2021
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	cc "github.com/camunda-community-hub/camunda-cloud-go-client/pkg/cc/client"
+	"github.com/spf13/cobra"
+)
+
+var cloneExample = `
+
+  # Create an empty cluster with the channel, generation, plan and region of prod-eu
+  cc-ctl clusters clone prod-eu --name prod-eu-repro
+
+  # Also recreate its Zeebe clients, IP allowlist and connector secrets, with empty values
+  cc-ctl clusters clone prod-eu --name prod-eu-repro --zeebe-clients --ip-allowlist --connector-secrets`
+
+// clusterCloneFlags are the flags of the clusters clone command.
+type clusterCloneFlags struct {
+	name         string
+	cloneOptions cc.CloneOptions
+	output       string
+}
+
+func CreateClustersCloneCmd(cli *CLI) *cobra.Command {
+	flags := &clusterCloneFlags{}
+
+	cloneCmd := &cobra.Command{
+		Use:   "clone <source>",
+		Short: "Create an empty cluster configured like another one",
+		Long: `Creates an empty cluster with the channel, generation, plan and region of the source cluster and, when asked,
+recreates its Zeebe clients with their scopes, its IP allowlist and its connector secrets with empty values,
+once the new cluster is healthy. It prints what was and was not copied. For example:` + cloneExample,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if flags.output != "text" && flags.output != "json" {
+				return fmt.Errorf("--output should be text or json: %s", flags.output)
+			}
+
+			sourceID, err := clusterIDOf(cmd.Context(), cli, args[0])
+			if err != nil {
+				return err
+			}
+
+			report, err := cli.Client.CloneCluster(cmd.Context(), sourceID, flags.name, flags.cloneOptions)
+			if report.ClusterID != "" {
+				cli.onCancel(fmt.Sprintf("cluster %s (%s)", report.Name, report.ClusterID), func(ctx context.Context) error {
+					_, err := cli.Client.DeleteClusterWithContext(ctx, report.ClusterID)
+					return err
+				})
+			}
+			if err != nil {
+				return err
+			}
+
+			if flags.output == "json" {
+				if err := showJSON(cli.Out, report); err != nil {
+					return err
+				}
+			} else if err := showCloneReport(cli.Out, report); err != nil {
+				return err
+			}
+			return report.Err()
+		},
+	}
+
+	cloneCmd.Flags().StringVarP(&flags.name, "name", "n", "", "Name of the new cluster")
+	cloneCmd.Flags().BoolVar(&flags.cloneOptions.ZeebeClients, "zeebe-clients", false, "Recreate the Zeebe clients with their names and scopes, they get new secrets")
+	cloneCmd.Flags().BoolVar(&flags.cloneOptions.IPAllowlist, "ip-allowlist", false, "Copy the IP allowlist")
+	cloneCmd.Flags().BoolVar(&flags.cloneOptions.ConnectorSecrets, "connector-secrets", false, "Recreate the connector secrets with their names and empty values")
+	cloneCmd.Flags().DurationVar(&flags.cloneOptions.PollInterval, "poll-interval", cc.DefaultPollInterval, "Time between two checks of the clone status, before copying to it")
+	cloneCmd.Flags().StringVarP(&flags.output, "output", "o", "text", "Output format: text or json")
+	cloneCmd.MarkFlagRequired("name")
+
+	return cloneCmd
+}
+
+// clusterIDOf returns the id of the cluster with this name, or the argument itself when no cluster has it.
+func clusterIDOf(ctx context.Context, cli *CLI, nameOrID string) (string, error) {
+	cluster, err := cli.Client.GetClusterByNameWithContext(ctx, nameOrID)
+	if err != nil {
+		return "", err
+	}
+	if cluster.ID != "" {
+		return cluster.ID, nil
+	}
+	return nameOrID, nil
+}
+
+// showCloneReport prints a line per item of the source cluster and a summary.
+func showCloneReport(out io.Writer, report cc.CloneReport) error {
+	fmt.Fprintf(out, "Cloned %s (%s) into %s (%s)\n\n", report.SourceName, report.SourceID, report.Name, report.ClusterID)
+
+	w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "RESULT\tWHAT\tNAME\tDETAILS")
+	show := func(result string, items []cc.CloneItem) {
+		for _, item := range items {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", result, item.What, valueOrDash(item.Name), valueOrDash(item.Details+item.Error))
+		}
+	}
+	show("copied", report.Copied)
+	show("not copied", report.NotCopied)
+	show("failed", report.Failed)
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "\n%d copied, %d not copied, %d failed.\n", len(report.Copied), len(report.NotCopied), len(report.Failed))
+	return nil
+}
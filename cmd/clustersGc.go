@@ -0,0 +1,138 @@
+/*
+Copyright © 2021
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	cc "github.com/camunda-community-hub/camunda-cloud-go-client/pkg/cc/client"
+	"github.com/spf13/cobra"
+)
+
+var gcExample = `
+
+  # Show the clusters starting with ci- created more than a day ago, without deleting them
+  cc-ctl clusters gc --older-than 24h --name-prefix ci- --dry-run
+
+  # Delete them, except the ones starting with ci-keep-
+  cc-ctl clusters gc --older-than 24h --name-prefix ci- --exclude 'ci-keep-*'
+
+  # Delete every cluster of the organization created more than a week ago
+  cc-ctl clusters gc --older-than 168h --all`
+
+// clusterGcFlags are the flags of the clusters gc command.
+type clusterGcFlags struct {
+	namePrefix string
+	nameGlob   string
+	nameRegex  string
+	plan       string
+	all        bool
+	gcOptions  cc.GCOptions
+	output     string
+}
+
+func CreateClustersGcCmd(cli *CLI) *cobra.Command {
+	flags := &clusterGcFlags{}
+
+	gcCmd := &cobra.Command{
+		Use:   "gc",
+		Short: "Delete stale clusters",
+		Long: `Deletes the clusters matching the name and plan filters that were created longer ago than --older-than,
+a few at a time, and reports what was deleted, skipped or failed. One of --name-prefix, --name-glob or --name-regex
+is required, and --older-than should be positive, unless --all is given. For example:` + gcExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if flags.output != "text" && flags.output != "json" {
+				return fmt.Errorf("--output should be text or json: %s", flags.output)
+			}
+
+			if flags.namePrefix == "" && flags.nameGlob == "" && flags.nameRegex == "" && !flags.all {
+				return fmt.Errorf("--name-prefix, --name-glob or --name-regex is required, or --all to consider every cluster of the organization")
+			}
+			if flags.gcOptions.OlderThan <= 0 && !flags.all {
+				return fmt.Errorf("--older-than should be positive, or --all given to delete clusters of any age: %s", flags.gcOptions.OlderThan)
+			}
+
+			filter := cc.ListOptions{Plan: flags.plan}
+			var err error
+			if filter.Name, filter.NameMatch, err = nameFilter(flags.namePrefix, flags.nameGlob, flags.nameRegex); err != nil {
+				return err
+			}
+
+			opts := flags.gcOptions
+			opts.Now = cli.Now
+			report, err := cli.Client.DeleteClustersMatching(cmd.Context(), filter, opts)
+			if err != nil {
+				return err
+			}
+
+			if flags.output == "json" {
+				if err := showJSON(cli.Out, report); err != nil {
+					return err
+				}
+			} else if err := showGCReport(cli.Out, report); err != nil {
+				return err
+			}
+			return report.Err()
+		},
+	}
+
+	gcCmd.Flags().DurationVar(&flags.gcOptions.OlderThan, "older-than", 0, "Only delete clusters created longer ago than this, for example 24h")
+	gcCmd.Flags().StringVar(&flags.namePrefix, "name-prefix", "", "Only delete clusters whose name starts with this prefix")
+	gcCmd.Flags().StringVar(&flags.nameGlob, "name-glob", "", "Only delete clusters whose name matches this glob pattern")
+	gcCmd.Flags().StringVar(&flags.nameRegex, "name-regex", "", "Only delete clusters whose name matches this regular expression")
+	gcCmd.Flags().StringVar(&flags.plan, "plan", "", "Only delete clusters with this plan type (id or name)")
+	gcCmd.Flags().BoolVar(&flags.all, "all", false, "Allow deleting clusters of any name, and of any age with --older-than 0s")
+	gcCmd.Flags().StringSliceVar(&flags.gcOptions.Exclude, "exclude", nil, "Never delete clusters whose name matches this glob pattern, can be repeated")
+	gcCmd.Flags().BoolVar(&flags.gcOptions.DryRun, "dry-run", false, "Only show what would be deleted")
+	gcCmd.Flags().IntVar(&flags.gcOptions.Concurrency, "concurrency", 4, "Number of clusters deleted in parallel")
+	gcCmd.Flags().StringVarP(&flags.output, "output", "o", "text", "Output format: text or json")
+	gcCmd.MarkFlagRequired("older-than")
+
+	return gcCmd
+}
+
+// showGCReport prints a line per cluster and a summary.
+func showGCReport(out io.Writer, report cc.GCReport) error {
+	deleted := "deleted"
+	if report.DryRun {
+		deleted = "would delete"
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "ACTION\tID\tNAME\tCREATED\tDETAILS")
+	show := func(action string, entries []cc.GCEntry) {
+		for _, entry := range entries {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", action, entry.ClusterID, entry.Name,
+				entry.Created.UTC().Format(time.RFC3339), valueOrDash(entry.Reason+entry.Error))
+		}
+	}
+	show(deleted, report.Deleted)
+	show("skipped", report.Skipped)
+	show("failed", report.Failed)
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if report.DryRun {
+		fmt.Fprintf(out, "\n%d would be deleted, %d skipped.\n", len(report.Deleted), len(report.Skipped))
+	} else {
+		fmt.Fprintf(out, "\n%d deleted, %d skipped, %d failed.\n", len(report.Deleted), len(report.Skipped), len(report.Failed))
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+/*
+Copyright © 2021
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+
+	cc "github.com/camunda-community-hub/camunda-cloud-go-client/pkg/cc/client"
+	"github.com/spf13/cobra"
+)
+
+var outdatedExample = `
+
+  # Compare the generation of every cluster with the ones of its channel
+  cc-ctl clusters outdated
+
+  # In a nightly job, fail when a production cluster is behind its channel
+  cc-ctl clusters outdated --plan 'Production - S' --fail-if-outdated -o json`
+
+// clusterOutdatedFlags are the flags of the clusters outdated command.
+type clusterOutdatedFlags struct {
+	namePrefix     string
+	nameGlob       string
+	nameRegex      string
+	channel        string
+	plan           string
+	output         string
+	failIfOutdated bool
+}
+
+func CreateClustersOutdatedCmd(cli *CLI) *cobra.Command {
+	flags := &clusterOutdatedFlags{}
+
+	outdatedCmd := &cobra.Command{
+		Use:   "outdated",
+		Short: "Compare the generation of the clusters with the ones of their channel",
+		Long: `Prints the current generation of each cluster next to the default generation of its channel, which is
+the recommended one, and the newest generation the channel allows. GAP is the most significant part of the version
+that differs from the recommended generation, BEHIND the number of newer generations on the channel. The API does
+not tell when a cluster was upgraded, so DAYS counts from its creation. A cluster is outdated when its generation is
+older than the recommended one, or not allowed on its channel anymore. For example:` + outdatedExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if flags.output != "table" && flags.output != "json" {
+				return fmt.Errorf("--output should be table or json: %s", flags.output)
+			}
+
+			filter := cc.ListOptions{Channel: flags.channel, Plan: flags.plan, SortBy: cc.SortByName}
+			var err error
+			if filter.Name, filter.NameMatch, err = nameFilter(flags.namePrefix, flags.nameGlob, flags.nameRegex); err != nil {
+				return err
+			}
+
+			params, err := cli.Client.GetClusterParamsWithContext(cmd.Context())
+			if err != nil {
+				return err
+			}
+			clusters, err := cli.Client.ListClusters(cmd.Context(), filter)
+			if err != nil {
+				return err
+			}
+
+			now := cli.Now()
+			reports := []cc.GenerationReport{}
+			outdated := 0
+			for _, cluster := range clusters {
+				report := cc.NewGenerationReport(cluster, *params, now)
+				if report.Outdated {
+					outdated++
+				}
+				reports = append(reports, report)
+			}
+
+			if flags.output == "json" {
+				if err := showJSON(cli.Out, reports); err != nil {
+					return err
+				}
+			} else if err := showGenerationReports(cli.Out, reports, outdated); err != nil {
+				return err
+			}
+
+			if flags.failIfOutdated && outdated > 0 {
+				return fmt.Errorf("%d of %d clusters are outdated", outdated, len(reports))
+			}
+			return nil
+		},
+	}
+
+	outdatedCmd.Flags().StringVar(&flags.namePrefix, "name-prefix", "", "Only check clusters whose name starts with this prefix")
+	outdatedCmd.Flags().StringVar(&flags.nameGlob, "name-glob", "", "Only check clusters whose name matches this glob pattern")
+	outdatedCmd.Flags().StringVar(&flags.nameRegex, "name-regex", "", "Only check clusters whose name matches this regular expression")
+	outdatedCmd.Flags().StringVar(&flags.channel, "channel", "", "Only check clusters on this channel (id or name)")
+	outdatedCmd.Flags().StringVar(&flags.plan, "plan", "", "Only check clusters with this plan type (id or name)")
+	outdatedCmd.Flags().StringVarP(&flags.output, "output", "o", "table", "Output format: table or json")
+	outdatedCmd.Flags().BoolVar(&flags.failIfOutdated, "fail-if-outdated", false, "Exit with 1 when a cluster is outdated")
+
+	return outdatedCmd
+}
+
+// showGenerationReports prints a line per cluster and a summary.
+func showGenerationReports(out io.Writer, reports []cc.GenerationReport, outdated int) error {
+	w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "NAME\tCHANNEL\tGENERATION\tRECOMMENDED\tLATEST\tGAP\tBEHIND\tDAYS\tSTATUS")
+	for _, report := range reports {
+		status := "up to date"
+		if report.Outdated {
+			status = "outdated"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%d\t%s\t%s\n", report.Name, valueOrDash(report.Channel),
+			valueOrDash(report.Generation), valueOrDash(report.Recommended), valueOrDash(report.Latest),
+			report.Gap, report.Behind, days(report), status)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "\n%d of %d clusters are outdated.\n", outdated, len(reports))
+	return nil
+}
+
+func days(report cc.GenerationReport) string {
+	if report.Since.IsZero() {
+		return "-"
+	}
+	return strconv.Itoa(report.Days)
+}
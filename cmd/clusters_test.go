@@ -1,8 +1,13 @@
 package cmd
 
 import (
+	"context"
 	"github.com/stretchr/testify/assert"
 	"testing"
+	"time"
+
+	"github.com/camunda-community-hub/camunda-cloud-go-client/pkg/cc/cctest"
+	cc "github.com/camunda-community-hub/camunda-cloud-go-client/pkg/cc/client"
 )
 
 func Test_getClusterParams(t *testing.T) {
@@ -12,3 +17,218 @@ func Test_getClusterParams(t *testing.T) {
 }
 
 
+
+func Test_parseTimeFlag(t *testing.T) {
+	parsed, err := parseTimeFlag("created-before", "2021-03-05")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2021, 3, 5, 0, 0, 0, 0, time.UTC), parsed)
+
+	parsed, err = parseTimeFlag("created-before", "")
+	assert.NoError(t, err)
+	assert.True(t, parsed.IsZero())
+
+	_, err = parseTimeFlag("created-before", "yesterday")
+	assert.EqualError(t, err, "--created-before should be a RFC3339 time or a YYYY-MM-DD date: yesterday")
+}
+
+func Test_ClustersCreateAndGet(t *testing.T) {
+	srv := newTestServer(t)
+
+	result := runCLI(t, srv, "clusters", "create", "--default", "--name", "orders")
+	assert.Equal(t, cliResult{Stdout: "Cluster created successfully. Cluster id: 00000000-0000-4000-8000-000000000001\n"}, result)
+
+	result = runCLI(t, srv, "clusters", "create", "--name", "payments",
+		"--channel", "channel-stable", "--generation", "generation-stable-1", "--region", "region-us-east1", "--plan", "plan-production-s")
+	assert.Equal(t, cliResult{Stdout: "Cluster created successfully. Cluster id: 00000000-0000-4000-8000-000000000002\n"}, result)
+
+	result = runCLI(t, srv, "clusters", "get", "--all", "--sort-by", "name")
+	assert.Equal(t, 0, result.ExitCode)
+	assert.Empty(t, result.Stderr)
+	assertGolden(t, "clusters_get_all.json", result.Stdout)
+
+	result = runCLI(t, srv, "clusters", "get", "--all", "--sort-by", "name", "--output", "table")
+	assert.Equal(t, 0, result.ExitCode)
+	assertGolden(t, "clusters_get_all.table", result.Stdout)
+}
+
+func Test_ClustersGet_status(t *testing.T) {
+	srv := newTestServer(t)
+	srv.OmitListingStatus = true
+	runCLI(t, srv, "clusters", "create", "--default", "--name", "orders")
+
+	result := runCLI(t, srv, "clusters", "get", "--all", "--status")
+
+	assert.Equal(t, 0, result.ExitCode)
+	assert.Empty(t, result.Stderr)
+	assertGolden(t, "clusters_get_all_status.json", result.Stdout)
+	assert.Len(t, srv.RequestsTo("GET /clusters/{clusterId}"), 1)
+}
+
+func Test_ClustersCreate_defaultAndTemplate(t *testing.T) {
+	srv := newTestServer(t)
+
+	result := runCLI(t, srv, "clusters", "create", "--default", "--template", "staging", "--name", "orders")
+
+	assert.Equal(t, cliResult{Stderr: "Error: --default and --template cannot be specified together\n", ExitCode: 1}, result)
+	assert.Empty(t, srv.Clusters())
+}
+
+func Test_ClustersCreate_duplicateName(t *testing.T) {
+	srv := newTestServer(t)
+	srv.AddCluster(cc.Cluster{Name: "orders"})
+
+	result := runCLI(t, srv, "clusters", "create", "--default", "--name", "orders")
+
+	assert.Equal(t, cliResult{Stderr: "Error: Cluster name already exists on Camunda Cloud\n", ExitCode: 1}, result)
+}
+
+func Test_ClustersGet_invalidFlags(t *testing.T) {
+	srv := newTestServer(t)
+
+	result := runCLI(t, srv, "clusters", "get", "--all", "--name", "orders")
+	assert.Equal(t, cliResult{Stderr: "Error: --all and --name cannot be specified together\n", ExitCode: 1}, result)
+
+	result = runCLI(t, srv, "clusters", "get", "--all", "--limit", "many")
+	assert.Equal(t, 1, result.ExitCode)
+	assert.Contains(t, result.Stderr, `invalid argument "many" for "--limit" flag`)
+	assert.Contains(t, result.Stdout, "Usage:")
+}
+
+func Test_ClustersGc(t *testing.T) {
+	srv := newTestServer(t)
+	old := testNow().Add(-48 * time.Hour)
+	srv.AddCluster(cc.Cluster{Name: "ci-1", Created: old})
+	srv.AddCluster(cc.Cluster{Name: "ci-keep", Created: old})
+	srv.AddCluster(cc.Cluster{Name: "ci-new", Created: testNow().Add(-time.Hour)})
+	srv.AddCluster(cc.Cluster{Name: "ci-2", Created: old})
+	srv.AddCluster(cc.Cluster{Name: "production", Created: old})
+
+	result := runCLI(t, srv, "clusters", "gc", "--older-than", "24h", "--name-prefix", "ci-", "--exclude", "ci-keep", "--dry-run")
+	assert.Equal(t, 0, result.ExitCode, result.Stderr)
+	assertGolden(t, "clusters_gc_dry_run", result.Stdout)
+	assert.Empty(t, srv.RequestsTo("DELETE /clusters/{clusterId}"))
+
+	srv.InjectFault("DELETE /clusters/{clusterId}", cctest.Fail(500, 1))
+	result = runCLI(t, srv, "clusters", "gc", "--older-than", "24h", "--name-prefix", "ci-", "--exclude", "ci-keep", "--concurrency", "1")
+	assert.Equal(t, 1, result.ExitCode)
+	assert.Equal(t, "Error: failed to delete 1 of 2 clusters: ci-1: HTTP Error trying to deleteCluster: 500\n", result.Stderr)
+	assertGolden(t, "clusters_gc", result.Stdout)
+}
+
+func Test_ClustersGc_olderThanRequired(t *testing.T) {
+	srv := newTestServer(t)
+
+	result := runCLI(t, srv, "clusters", "gc", "--name-prefix", "ci-")
+
+	assert.Equal(t, 1, result.ExitCode)
+	assert.Contains(t, result.Stderr, `required flag(s) "older-than" not set`)
+	assert.Empty(t, srv.RequestsTo("DELETE /clusters/{clusterId}"))
+}
+
+func Test_ClustersGc_refusesWholeOrganization(t *testing.T) {
+	srv := newTestServer(t)
+	srv.AddCluster(cc.Cluster{Name: "production", Created: testNow().Add(-48 * time.Hour)})
+
+	result := runCLI(t, srv, "clusters", "gc", "--older-than", "24h")
+	assert.Equal(t, cliResult{Stderr: "Error: --name-prefix, --name-glob or --name-regex is required, or --all to consider every cluster of the organization\n", ExitCode: 1}, result)
+
+	result = runCLI(t, srv, "clusters", "gc", "--older-than", "0s", "--name-prefix", "prod")
+	assert.Equal(t, cliResult{Stderr: "Error: --older-than should be positive, or --all given to delete clusters of any age: 0s\n", ExitCode: 1}, result)
+	assert.Empty(t, srv.RequestsTo("DELETE /clusters/{clusterId}"))
+
+	result = runCLI(t, srv, "clusters", "gc", "--older-than", "24h", "--all")
+	assert.Equal(t, 0, result.ExitCode, result.Stderr)
+	assert.Len(t, srv.RequestsTo("DELETE /clusters/{clusterId}"), 1)
+}
+
+func Test_ClustersCreate_ifNotExists(t *testing.T) {
+	srv := newTestServer(t)
+	args := []string{"clusters", "create", "--name", "payments", "--if-not-exists",
+		"--channel", "channel-stable", "--generation", "generation-stable-1", "--region", "region-us-east1", "--plan", "plan-production-s"}
+
+	result := runCLI(t, srv, args...)
+	assert.Equal(t, cliResult{Stdout: "Cluster created successfully. Cluster id: 00000000-0000-4000-8000-000000000001\n"}, result)
+
+	result = runCLI(t, srv, args...)
+	assert.Equal(t, cliResult{Stdout: "Cluster already exists. Cluster id: 00000000-0000-4000-8000-000000000001\n"}, result)
+
+	result = runCLI(t, srv, "clusters", "create", "--default", "--name", "payments", "--if-not-exists")
+	assert.Equal(t, cliResult{Stderr: "Error: cluster payments (00000000-0000-4000-8000-000000000001) already exists with other settings: " +
+		"plan is Production - S, want Development; generation is Zeebe 0.26.1, want Zeebe 1.0.0; region is US East, want Europe West\n", ExitCode: 1}, result)
+	assert.Len(t, srv.Clusters(), 1)
+}
+
+func Test_ClustersCreate_rollbackOnCancel(t *testing.T) {
+	srv := newTestServer(t)
+	srv.CreatingPolls = 1000000
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	result := runCLIContext(t, srv, ctx, "--rollback-on-cancel", "clusters", "create", "--default", "--name", "orders",
+		"--wait", "--poll-interval", "1ms")
+
+	assert.Equal(t, 1, result.ExitCode)
+	assert.Contains(t, result.Stderr, "Rolling back: cluster orders (00000000-0000-4000-8000-000000000001)\n")
+	assert.Len(t, srv.RequestsTo("DELETE /clusters/{clusterId}"), 1)
+}
+
+func Test_ClustersCreate_cancelLeavesClusterBehind(t *testing.T) {
+	srv := newTestServer(t)
+	srv.CreatingPolls = 1000000
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	result := runCLIContext(t, srv, ctx, "clusters", "create", "--default", "--name", "orders",
+		"--wait", "--poll-interval", "1ms")
+
+	assert.Equal(t, 1, result.ExitCode)
+	assert.Contains(t, result.Stderr, "Canceled, left behind:\n  cluster orders (00000000-0000-4000-8000-000000000001)\n")
+	assert.Empty(t, srv.RequestsTo("DELETE /clusters/{clusterId}"))
+}
+
+func Test_ClustersClone(t *testing.T) {
+	srv := newTestServer(t)
+	params := srv.Params()
+	source := srv.AddCluster(cc.Cluster{
+		Name:             "prod-eu",
+		Channel:          params.Channels[0],
+		Generation:       params.Channels[0].DefaultGeneration,
+		ClusterPlantType: params.ClusterPlanTypes[1],
+		K8sContext:       cc.K8sContext{UUID: params.Regions[0].Id, Name: params.Regions[0].Name},
+		IPAllowlist:      []cc.IPAllowlistEntry{{Description: "office", IP: "10.0.0.0/8"}, {Description: "vpn", IP: "192.168.1.1"}},
+	})
+	srv.AddZeebeClient(source.ID, "worker", cc.ZeebeClientScopeZeebe, cc.ZeebeClientScopeOperate)
+	srv.AddConnectorSecret(source.ID, "API_KEY", "secret")
+
+	result := runCLI(t, srv, "clusters", "clone", "prod-eu", "--name", "prod-eu-repro", "--zeebe-clients", "--ip-allowlist", "--poll-interval", "1ms")
+
+	assert.Equal(t, 0, result.ExitCode)
+	assert.Empty(t, result.Stderr)
+	assertGolden(t, "clusters_clone", result.Stdout)
+	assert.Len(t, srv.Clusters(), 2)
+}
+
+func Test_ClustersClone_unknownSource(t *testing.T) {
+	srv := newTestServer(t)
+
+	result := runCLI(t, srv, "clusters", "clone", "prod-eu", "--name", "prod-eu-repro")
+
+	assert.Equal(t, cliResult{Stderr: "Error: HTTP Error trying to getCluster: 404\n", ExitCode: 1}, result)
+}
+
+func Test_ClustersOutdated(t *testing.T) {
+	srv := newTestServer(t)
+	stable := srv.Params().Channels[0]
+	for _, generation := range stable.AllowedGeneration {
+		srv.AddCluster(cc.Cluster{Name: "on-" + generation.Name, Channel: stable, Generation: generation, Created: testNow().Add(-72 * time.Hour)})
+	}
+
+	result := runCLI(t, srv, "clusters", "outdated")
+	assert.Equal(t, 0, result.ExitCode, result.Stderr)
+	assertGolden(t, "clusters_outdated", result.Stdout)
+
+	result = runCLI(t, srv, "clusters", "outdated", "--fail-if-outdated", "-o", "json")
+	assert.Equal(t, 1, result.ExitCode)
+	assert.Equal(t, "Error: 1 of 2 clusters are outdated\n", result.Stderr)
+	assertGolden(t, "clusters_outdated.json", result.Stdout)
+}
@@ -0,0 +1,122 @@
+/*
+Copyright © 2021
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"text/tabwriter"
+
+	cc "github.com/camunda-community-hub/camunda-cloud-go-client/pkg/cc/client"
+	"github.com/spf13/cobra"
+)
+
+var compareExample = `
+
+  # Compare the clusters with the same names in the dev and prod organizations
+  cc-ctl compare --context dev --context prod
+
+  # Match dev-orders and staging-orders with prod-orders
+  cc-ctl compare --context dev --context staging --context prod --match '^(?:dev|staging|prod)-(.*)$'`
+
+// compareFlags are the flags of the compare command.
+type compareFlags struct {
+	contexts []string
+	match    string
+	output   string
+}
+
+func CreateCompareCmd(cli *CLI) *cobra.Command {
+	flags := &compareFlags{}
+
+	compareCmd := &cobra.Command{
+		Use:   "compare",
+		Short: "Compare the clusters of organizations",
+		Long: `Logs in with each context of the config file given with --context and compares the clusters of their
+organizations. Clusters are matched by name, or by the part of their name extracted by --match: its first group,
+or the whole match when it has none. It reports the clusters missing from an organization, and the differences
+in generation, channel, plan, region, Zeebe client names and scopes and IP allowlist entries of the others.
+A dash stands for a cluster, Zeebe client or IP missing from an organization. For example:` + compareExample,
+		Args:        cobra.NoArgs,
+		Annotations: map[string]string{skipLoginAnnotation: "true"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if flags.output != "table" && flags.output != "json" {
+				return fmt.Errorf("--output should be table or json: %s", flags.output)
+			}
+			if len(flags.contexts) < 2 {
+				return fmt.Errorf("--context should be given at least twice, with the contexts to compare")
+			}
+			opts := cc.CompareOptions{}
+			if flags.match != "" {
+				var err error
+				if opts.NamePattern, err = regexp.Compile(flags.match); err != nil {
+					return fmt.Errorf("invalid --match: %v", err)
+				}
+			}
+
+			organizations := []cc.OrganizationSnapshot{}
+			for _, name := range flags.contexts {
+				ccClient, err := cli.newContextClient(cmd.Context(), name)
+				if err != nil {
+					return err
+				}
+				organization, err := ccClient.SnapshotOrganization(cmd.Context(), name)
+				if err != nil {
+					return fmt.Errorf("cannot list the clusters of context %q: %v", name, err)
+				}
+				organizations = append(organizations, organization)
+			}
+
+			report, err := cc.CompareOrganizations(organizations, opts)
+			if err != nil {
+				return err
+			}
+			if flags.output == "json" {
+				return showJSON(cli.Out, report)
+			}
+			return showCompareReport(cli.Out, report)
+		},
+	}
+
+	// Replaces the --context of the other commands, which selects a single context.
+	compareCmd.Flags().StringArrayVar(&flags.contexts, "context", nil, "Context of the config file to compare, given at least twice")
+	compareCmd.Flags().StringVar(&flags.match, "match", "", "Regular expression extracting the part of the cluster names to match clusters on")
+	compareCmd.Flags().StringVarP(&flags.output, "output", "o", "table", "Output format: table or json")
+
+	return compareCmd
+}
+
+// showCompareReport prints a line per difference with the value in each organization, and a summary.
+func showCompareReport(out io.Writer, report cc.CompareReport) error {
+	w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	fmt.Fprintf(w, "CLUSTER\tWHAT\tNAME\t%s\n", strings.ToUpper(strings.Join(report.Organizations, "\t")))
+	for _, difference := range report.Differences {
+		values := []string{}
+		for _, value := range difference.Values {
+			values = append(values, valueOrDash(value))
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", difference.Cluster, difference.What, valueOrDash(difference.Name), strings.Join(values, "\t"))
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "\n%d clusters found in all of %s, %d differences.\n",
+		report.Matched, strings.Join(report.Organizations, ", "), len(report.Differences))
+	return nil
+}
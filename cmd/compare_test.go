@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/camunda-community-hub/camunda-cloud-go-client/pkg/cc/cctest"
+	cc "github.com/camunda-community-hub/camunda-cloud-go-client/pkg/cc/client"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+// writeContextsConfig writes a config file with a context per server.
+func writeContextsConfig(t *testing.T, servers map[string]*cctest.Server) string {
+	content := "contexts:\n"
+	for name, srv := range servers {
+		content += fmt.Sprintf("  %s:\n    client-id: cctest\n    client-secret: cctest\n    api-url: %s\n    login-url: %s\n", name, srv.URL, srv.URL)
+	}
+	return writeConfig(t, content)
+}
+
+func Test_Compare(t *testing.T) {
+	dev, prod := newTestServer(t), newTestServer(t)
+	params := dev.Params()
+	stable := params.Channels[0]
+	region := cc.K8sContext{UUID: params.Regions[0].Id, Name: params.Regions[0].Name}
+
+	devOrders := dev.AddCluster(cc.Cluster{Name: "dev-orders", Channel: stable, Generation: stable.DefaultGeneration,
+		ClusterPlantType: params.ClusterPlanTypes[0], K8sContext: region})
+	dev.AddZeebeClient(devOrders.ID, "worker", cc.ZeebeClientScopeZeebe)
+	dev.AddZeebeClient(devOrders.ID, "starter", cc.ZeebeClientScopeZeebe)
+	prodOrders := prod.AddCluster(cc.Cluster{Name: "prod-orders", Channel: stable, Generation: stable.AllowedGeneration[0],
+		ClusterPlantType: params.ClusterPlanTypes[1], K8sContext: region,
+		IPAllowlist: []cc.IPAllowlistEntry{{Description: "office", IP: "10.0.0.0/8"}}})
+	prod.AddZeebeClient(prodOrders.ID, "worker", cc.ZeebeClientScopeZeebe, cc.ZeebeClientScopeOperate)
+	prod.AddCluster(cc.Cluster{Name: "prod-billing", Channel: stable, Generation: stable.DefaultGeneration, K8sContext: region})
+	config := writeContextsConfig(t, map[string]*cctest.Server{"dev": dev, "prod": prod})
+
+	result := runCLI(t, dev, "compare", "--config", config, "--context", "dev", "--context", "prod", "--match", "^(?:dev|prod)-(.*)$")
+
+	assert.Equal(t, 0, result.ExitCode, result.Stderr)
+	assertGolden(t, "compare", result.Stdout)
+	assert.Len(t, prod.RequestsTo("POST /oauth/token"), 1)
+}
+
+func Test_Compare_needsTwoContexts(t *testing.T) {
+	srv := newTestServer(t)
+
+	result := runCLI(t, srv, "compare", "--context", "cctest")
+
+	assert.Equal(t, cliResult{Stderr: "Error: --context should be given at least twice, with the contexts to compare\n", ExitCode: 1}, result)
+	assert.Empty(t, srv.RequestsTo("POST /oauth/token"))
+}
+
+func Test_Compare_unknownContext(t *testing.T) {
+	srv := newTestServer(t)
+
+	result := runCLI(t, srv, "compare", "--context", "cctest", "--context", "staging")
+
+	assert.Equal(t, cliResult{Stderr: "Error: context \"staging\" not found in the config file\n", ExitCode: 1}, result)
+}
+
+func Test_newContextClient_sameSetup(t *testing.T) {
+	prod := newTestServer(t)
+	client := &cc.CCClient{}
+	client.Use(cc.UserAgent("cc-ctl"), cc.RequestID())
+	config := viper.New()
+	config.Set("contexts", map[string]interface{}{
+		"prod": map[string]interface{}{"client-id": "cctest", "client-secret": "cctest", "api-url": prod.URL, "login-url": prod.URL},
+	})
+	cli := &CLI{Client: client, Config: config}
+
+	prodClient, err := cli.newContextClient(context.Background(), "prod")
+	assert.NoError(t, err)
+	_, err = prodClient.GetClusters()
+	assert.NoError(t, err)
+
+	requests := prod.Requests()
+	assert.Len(t, requests, 2)
+	for _, request := range requests {
+		assert.Equal(t, "cc-ctl", request.Header.Get("User-Agent"), request.Route)
+		assert.NotEmpty(t, request.Header.Get(cc.RequestIDHeader), request.Route)
+	}
+}
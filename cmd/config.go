@@ -0,0 +1,220 @@
+/*
+Copyright © 2021
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	gocontext "context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	cc "github.com/camunda-community-hub/camunda-cloud-go-client/pkg/cc/client"
+	homedir "github.com/mitchellh/go-homedir"
+	"github.com/spf13/viper"
+)
+
+// Context is a named set of credentials and endpoints stored in the config file:
+//
+//	current-context: local
+//	contexts:
+//	  local:
+//	    client-id: demo
+//	    client-secret: demo
+//	    api-url: http://localhost:8080
+//	    login-url: http://localhost:8080
+type Context struct {
+	Name         string `mapstructure:"-"`
+	ClientID     string `mapstructure:"client-id"`
+	ClientSecret string `mapstructure:"client-secret"`
+	// CCApiURL is the Camunda Cloud domain, cloud.camunda.io by default.
+	CCApiURL string `mapstructure:"cc-api-url"`
+	// APIURL and LoginURL replace the URLs derived from CCApiURL, for example to target a mock server.
+	APIURL   string `mapstructure:"api-url"`
+	LoginURL string `mapstructure:"login-url"`
+}
+
+// loadContext reads a context from the config.
+func loadContext(config *viper.Viper, name string) (Context, error) {
+	context := Context{}
+	key := "contexts." + name
+	if !config.IsSet(key) {
+		return context, fmt.Errorf("context %q not found in the config file", name)
+	}
+	if err := config.UnmarshalKey(key, &context); err != nil {
+		return context, fmt.Errorf("invalid context %q: %v", name, err)
+	}
+	context.Name = name
+	return context, nil
+}
+
+// channelDefaultGeneration is the generation of a template that follows the default generation of its channel.
+const channelDefaultGeneration = "default"
+
+// Template is a named cluster configuration stored in the config file, with the plan, channel,
+// generation and region given by name. The generation is either pinned, or "default" (or empty)
+// to use the default generation of the channel at creation time:
+//
+//	templates:
+//	  staging:
+//	    plan: Production - S
+//	    channel: Stable
+//	    generation: default
+//	    region: Europe West
+type Template struct {
+	Name       string `mapstructure:"-" json:"name"`
+	Plan       string `mapstructure:"plan" json:"plan,omitempty"`
+	Channel    string `mapstructure:"channel" json:"channel,omitempty"`
+	Generation string `mapstructure:"generation" json:"generation,omitempty"`
+	Region     string `mapstructure:"region" json:"region,omitempty"`
+}
+
+// spec is the specification of a cluster created from the template.
+func (t Template) spec(clusterName string) cc.ClusterSpec {
+	spec := cc.ClusterSpec{Name: clusterName, Plan: t.Plan, Channel: t.Channel, Generation: t.Generation, Region: t.Region}
+	if strings.EqualFold(spec.Generation, channelDefaultGeneration) {
+		spec.Generation = ""
+	}
+	return spec
+}
+
+// loadTemplate reads a template from the config.
+func loadTemplate(config *viper.Viper, name string) (Template, error) {
+	template := Template{}
+	key := "templates." + name
+	if !config.IsSet(key) {
+		return template, fmt.Errorf("template %q not found in the config file", name)
+	}
+	if err := config.UnmarshalKey(key, &template); err != nil {
+		return template, fmt.Errorf("invalid template %q: %v", name, err)
+	}
+	template.Name = name
+	return template, nil
+}
+
+// loadTemplates reads all the templates of the config, sorted by name.
+func loadTemplates(config *viper.Viper) ([]Template, error) {
+	names := []string{}
+	for name := range config.GetStringMap("templates") {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	templates := []Template{}
+	for _, name := range names {
+		template, err := loadTemplate(config, name)
+		if err != nil {
+			return templates, err
+		}
+		templates = append(templates, template)
+	}
+	return templates, nil
+}
+
+// resolveContext returns the context selected with --context. Without --context the
+// current-context of the config file is used, and the CC_* environment variables take
+// precedence over its values.
+func resolveContext(config *viper.Viper, getenv func(string) string, name string) (Context, error) {
+	if name != "" {
+		return loadContext(config, name)
+	}
+
+	context := Context{}
+	if current := config.GetString("current-context"); current != "" {
+		var err error
+		if context, err = loadContext(config, current); err != nil {
+			return context, err
+		}
+	}
+
+	if clientId := getenv("CC_CLIENT_ID"); clientId != "" {
+		context.ClientID = clientId
+	}
+	if clientSecret := getenv("CC_CLIENT_SECRET"); clientSecret != "" {
+		context.ClientSecret = clientSecret
+	}
+	if ccApiURL := getenv("CC_API_URL"); ccApiURL != "" {
+		context.CCApiURL = ccApiURL
+	}
+	return context, nil
+}
+
+// readConfigFile reads the config file given with --config, or the one in the home directory.
+// A missing config file in the home directory is not an error.
+func readConfigFile(config *viper.Viper, cfgFile string, errOut io.Writer) error {
+	if cfgFile != "" {
+		// Use config file from the flag.
+		config.SetConfigFile(cfgFile)
+	} else {
+		// Find home directory.
+		home, err := homedir.Dir()
+		if err != nil {
+			return err
+		}
+
+		// Search config in home directory with name ".camunda-cloud-go-client" (without extension).
+		config.AddConfigPath(home)
+		config.SetConfigName(".camunda-cloud-go-client")
+	}
+
+	if err := config.ReadInConfig(); err != nil {
+		if _, notFound := err.(viper.ConfigFileNotFoundError); notFound && cfgFile == "" {
+			return nil
+		}
+		return err
+	}
+	fmt.Fprintln(errOut, "Using config file:", config.ConfigFileUsed())
+	return nil
+}
+
+// configureClient points the client at the endpoints of the context. Clients other than
+// *cc.CCClient have no endpoints and are left as they are.
+func configureClient(client cc.CCAPI, context Context) {
+	ccClient, ok := client.(*cc.CCClient)
+	if !ok {
+		return
+	}
+	ccApiURL := context.CCApiURL
+	if ccApiURL == "" {
+		ccApiURL = "cloud.camunda.io"
+	}
+	ccClient.SetCCApiURL(ccApiURL)
+	ccClient.SetAPIURL(context.APIURL)
+	ccClient.SetLoginURL(context.LoginURL)
+}
+
+// newContextClient returns a new client logged in with a context of the config file, for commands
+// talking to several organizations at once. It has the interceptors, HTTP client and tracing of cli.Client
+// when it is a *cc.CCClient.
+func (cli *CLI) newContextClient(ctx gocontext.Context, name string) (*cc.CCClient, error) {
+	context, err := loadContext(cli.Config, name)
+	if err != nil {
+		return nil, err
+	}
+	if !checkEnvVars(context.ClientID, context.ClientSecret) {
+		return nil, fmt.Errorf("context %q has no client-id or client-secret", name)
+	}
+
+	ccClient := &cc.CCClient{}
+	if client, ok := cli.Client.(*cc.CCClient); ok {
+		ccClient = client.CopySettings()
+	}
+	configureClient(ccClient, context)
+	if login, err := ccClient.LoginWithContext(ctx, context.ClientID, context.ClientSecret); err != nil || !login {
+		return nil, fmt.Errorf("cannot log in with context %q: %v", name, err)
+	}
+	return ccClient, nil
+}
@@ -0,0 +1,508 @@
+/*
+Copyright © 2021
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	cc "github.com/camunda-community-hub/camunda-cloud-go-client/pkg/cc/client"
+	"github.com/spf13/cobra"
+)
+
+var doctorExample = `
+
+  # Check the configuration, credentials and connectivity of cc-ctl
+  cc-ctl doctor
+
+  # Also check that the responses of Camunda Cloud still match the types of the client
+  cc-ctl doctor --schema
+
+  # Print the report as JSON
+  cc-ctl doctor -o json`
+
+// errDoctorFailed makes doctor exit with an error once its report is printed.
+var errDoctorFailed = fmt.Errorf("some checks failed")
+
+// maxClockSkew is the clock difference with the login server above which tokens
+// may look expired or not yet valid.
+const maxClockSkew = 30 * time.Second
+
+const (
+	checkPass = "pass"
+	checkWarn = "warn"
+	checkFail = "fail"
+	checkSkip = "skip"
+)
+
+// doctorCheck is a line of the doctor report.
+type doctorCheck struct {
+	Name    string `json:"name"`
+	Status  string `json:"status"`
+	Details string `json:"details,omitempty"`
+	Hint    string `json:"hint,omitempty"`
+}
+
+func CreateDoctorCmd(cli *CLI) *cobra.Command {
+	var schema bool
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Diagnose problems with Camunda Cloud and cc-ctl",
+		Long: `Checks, in order, the config and context resolution, the credentials, the token and its expiry,
+the clock skew with the login server, the granted scopes, the reachability of the API and login hosts,
+the loading of the cluster params and the tracer endpoint when tracing is enabled.
+Failed checks come with a hint to fix them. For example:` + doctorExample,
+		Annotations: map[string]string{skipLoginAnnotation: "true"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if output != "text" && output != "json" {
+				return fmt.Errorf("--output should be text or json: %s", output)
+			}
+
+			client, ok := cli.Client.(*cc.CCClient)
+			if !ok {
+				return fmt.Errorf("doctor checks the endpoints and token of the Camunda Cloud client, not of a %T", cli.Client)
+			}
+
+			d := &doctor{ctx: cmd.Context(), cli: cli, client: client, schema: schema}
+			d.run()
+
+			if output == "json" {
+				if err := showJSON(cli.Out, d.checks); err != nil {
+					return err
+				}
+			} else {
+				printDoctorChecks(cli.Out, d.checks)
+			}
+
+			if d.failed() {
+				return errDoctorFailed
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&schema, "schema", false, "Also check the responses of the read endpoints against the types of the client")
+	cmd.Flags().StringVarP(&output, "output", "o", "text", "Output format: text or json")
+
+	return cmd
+}
+
+// doctor runs the checks, each depending on the ones before it.
+type doctor struct {
+	ctx    context.Context
+	cli    *CLI
+	client *cc.CCClient
+	schema bool
+	checks []doctorCheck
+	drifts map[string]cc.SchemaDrift
+}
+
+func (d *doctor) add(name string, status string, details string, hint string) bool {
+	d.checks = append(d.checks, doctorCheck{Name: name, Status: status, Details: details, Hint: hint})
+	return status == checkPass || status == checkWarn
+}
+
+func (d *doctor) skip(reason string, names ...string) {
+	for _, name := range names {
+		d.add(name, checkSkip, reason, "")
+	}
+}
+
+func (d *doctor) failed() bool {
+	for _, check := range d.checks {
+		if check.Status == checkFail {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *doctor) run() {
+	ccClient := d.client
+	if d.schema {
+		d.drifts = map[string]cc.SchemaDrift{}
+		ccClient.SetSchemaDriftHandler(func(drift cc.SchemaDrift) {
+			d.drifts[drift.Operation] = drift
+		})
+		defer ccClient.SetSchemaDriftHandler(nil)
+	}
+
+	afterToken := []string{"token expiry", "clock skew", "scopes", "api host", "login host", "cluster params"}
+
+	context, ok := d.checkConfig()
+	if !ok {
+		d.skip("needs a context", append([]string{"credentials", "token"}, afterToken...)...)
+		d.checkTracer()
+		return
+	}
+
+	if !d.checkCredentials(context) {
+		d.skip("needs credentials", append([]string{"token"}, afterToken...)...)
+		d.checkTracer()
+		return
+	}
+
+	configureClient(ccClient, context)
+	d.cli.context = context
+
+	if !d.checkToken() {
+		d.skip("needs a token", "token expiry", "clock skew", "scopes")
+		d.checkHost("api host", ccClient.APIURL())
+		d.checkHost("login host", ccClient.LoginURL())
+		d.skip("needs a token", "cluster params")
+		d.checkTracer()
+		return
+	}
+
+	d.checkTokenExpiry()
+	d.checkClockSkew()
+	d.checkScopes()
+	d.checkHost("api host", ccClient.APIURL())
+	d.checkHost("login host", ccClient.LoginURL())
+	d.checkClusterParams()
+	d.checkTracer()
+
+	if d.schema {
+		d.checkSchema()
+	}
+}
+
+func (d *doctor) checkConfig() (Context, bool) {
+	context, err := resolveContext(d.cli.Config, d.cli.Getenv, d.cli.contextName)
+	if err != nil {
+		d.add("config", checkFail, err.Error(),
+			"check the contexts of the config file, or drop --context to use the CC_* environment variables")
+		return context, false
+	}
+
+	source := "no config file"
+	if file := d.cli.Config.ConfigFileUsed(); file != "" {
+		source = "config file " + file
+	}
+	switch {
+	case d.cli.contextName != "":
+		source += fmt.Sprintf(", context %q", context.Name)
+	case context.Name != "":
+		source += fmt.Sprintf(", current-context %q", context.Name)
+	default:
+		source += ", CC_* environment variables"
+	}
+	return context, d.add("config", checkPass, source, "")
+}
+
+func (d *doctor) checkCredentials(context Context) bool {
+	if !checkEnvVars(context.ClientID, context.ClientSecret) {
+		return d.add("credentials", checkFail, "client id or secret missing",
+			"export CC_CLIENT_ID and CC_CLIENT_SECRET, or set client-id and client-secret in the context. "+
+				"API clients are created in the Console under Organization Settings -> Cloud Management API")
+	}
+	return d.add("credentials", checkPass, "client id "+context.ClientID, "")
+}
+
+func (d *doctor) checkToken() bool {
+	ccClient := d.client
+	_, err := ccClient.LoginWithContext(d.ctx, d.cli.context.ClientID, d.cli.context.ClientSecret)
+	if err == nil {
+		return d.add("token", checkPass, ccClient.AuthResponsePayload.TokenType+" token from "+ccClient.LoginURL(), "")
+	}
+
+	httpErr := &cc.HTTPError{}
+	switch {
+	case errors.As(err, &httpErr) && (httpErr.StatusCode == http.StatusUnauthorized || httpErr.StatusCode == http.StatusForbidden):
+		return d.add("token", checkFail, err.Error(),
+			"the client id or secret is wrong, or the API client was deleted. Create a new one in the Console")
+	case errors.As(err, &httpErr):
+		return d.add("token", checkFail, err.Error(),
+			"the login server answered unexpectedly, check the login URL "+ccClient.LoginURL())
+	default:
+		return d.add("token", checkFail, err.Error(),
+			"the login server is unreachable, check the login URL, proxy settings and network")
+	}
+}
+
+// checkTokenExpiry compares the exp claim of the token with the local clock. The token was just
+// issued, so a clock off by more than maxClockSkew shows as a lifetime different from expires_in.
+func (d *doctor) checkTokenExpiry() {
+	payload := d.client.AuthResponsePayload
+	expiresIn := time.Duration(payload.ExpiresIn) * time.Second
+	expiry, ok := tokenExpiry(payload.AccessToken)
+	if !ok {
+		if expiresIn < 5*time.Minute {
+			d.add("token expiry", checkWarn, "expires in "+expiresIn.String()+", the token has no exp claim",
+				"long running commands may outlive the token")
+			return
+		}
+		d.add("token expiry", checkPass, "expires in "+expiresIn.String()+", the token has no exp claim", "")
+		return
+	}
+
+	remaining := expiry.Sub(d.cli.Now()).Round(time.Second)
+	skew := remaining - expiresIn
+	if skew < 0 {
+		skew = -skew
+	}
+	switch {
+	case remaining <= 0:
+		d.add("token expiry", checkFail, "the token expired at "+expiry.UTC().Format(time.RFC3339)+" by the clock of this machine",
+			"synchronize the clock of this machine, for example with NTP")
+	case payload.ExpiresIn > 0 && skew >= maxClockSkew:
+		d.add("token expiry", checkFail, fmt.Sprintf("expires in %s by the clock of this machine, %s according to the login server", remaining, expiresIn),
+			"synchronize the clock of this machine, for example with NTP")
+	case remaining < 5*time.Minute:
+		d.add("token expiry", checkWarn, "expires in "+remaining.String(), "long running commands may outlive the token")
+	default:
+		d.add("token expiry", checkPass, "expires in "+remaining.String(), "")
+	}
+}
+
+// tokenExpiry reads the exp claim of a JWT, without checking its signature.
+func tokenExpiry(token string) (time.Time, bool) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}, false
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return time.Time{}, false
+	}
+	claims := struct {
+		Exp int64 `json:"exp"`
+	}{}
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Exp <= 0 {
+		return time.Time{}, false
+	}
+	return time.Unix(claims.Exp, 0), true
+}
+
+// checkClockSkew compares the local clock with the Date header of the login server.
+func (d *doctor) checkClockSkew() {
+	resp, err := d.get(d.client.LoginURL())
+	if err != nil {
+		d.add("clock skew", checkSkip, "the login server is unreachable", "")
+		return
+	}
+	resp.Body.Close()
+
+	serverTime, err := http.ParseTime(resp.Header.Get("Date"))
+	if err != nil {
+		d.add("clock skew", checkSkip, "the login server does not send its time", "")
+		return
+	}
+
+	skew := time.Since(serverTime).Round(time.Second)
+	if skew < 0 {
+		skew = -skew
+	}
+	switch {
+	case skew >= maxClockSkew:
+		d.add("clock skew", checkFail, "off by "+skew.String()+" from the login server",
+			"synchronize the clock of this machine, for example with NTP")
+	case skew > 5*time.Second:
+		d.add("clock skew", checkWarn, "off by "+skew.String()+" from the login server", "")
+	default:
+		d.add("clock skew", checkPass, "in sync with the login server", "")
+	}
+}
+
+func (d *doctor) checkScopes() {
+	ccClient := d.client
+	granted := []string{}
+	for _, scope := range ccClient.GrantedScopes() {
+		granted = append(granted, string(scope))
+	}
+	if len(granted) == 0 {
+		// Like the client, which leaves the checks to the API for such tokens.
+		d.add("scopes", checkWarn, "the token does not list its scopes, they are not checked",
+			"commands needing a scope the API client lacks will fail with the error of the API")
+		return
+	}
+
+	missing := []string{}
+	for _, scope := range cc.Scopes {
+		if !ccClient.HasScope(scope) {
+			missing = append(missing, string(scope))
+		}
+	}
+	if len(missing) > 0 {
+		d.add("scopes", checkWarn, strings.Join(granted, ", ")+"; missing "+strings.Join(missing, ", "),
+			"the commands needing the missing scopes will fail, grant them to the API client in the Console")
+		return
+	}
+	d.add("scopes", checkPass, strings.Join(granted, ", "), "")
+}
+
+// checkHost tells whether the host answers HTTP at all, whatever the status.
+func (d *doctor) checkHost(name string, url string) {
+	resp, err := d.get(url)
+	if err != nil {
+		d.add(name, checkFail, err.Error(), "check the URL, the proxy settings (HTTPS_PROXY) and the firewall")
+		return
+	}
+	resp.Body.Close()
+	d.add(name, checkPass, url+" is reachable", "")
+}
+
+// get sends a plain GET request, outside of the API calls of the client.
+func (d *doctor) get(url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(d.ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	return d.client.HTTPClient().Do(req)
+}
+
+func (d *doctor) checkClusterParams() {
+	params, err := d.client.GetClusterParamsWithContext(d.ctx)
+	if err != nil {
+		d.add("cluster params", checkFail, err.Error(), "check that the API client has the Cluster scope")
+		return
+	}
+	if len(params.Channels) == 0 || len(params.ClusterPlanTypes) == 0 || len(params.Regions) == 0 {
+		d.add("cluster params", checkFail, "no channel, plan or region", "check that the API client has the Cluster scope")
+		return
+	}
+	d.add("cluster params", checkPass, fmt.Sprintf("%d channels, %d plans, %d regions",
+		len(params.Channels), len(params.ClusterPlanTypes), len(params.Regions)), "")
+}
+
+// checkTracer dials the Jaeger collector configured by the CC_TRACING_* environment variables.
+func (d *doctor) checkTracer() {
+	enabled, _ := strconv.ParseBool(d.cli.Getenv("CC_TRACING_ENABLED"))
+	if !enabled {
+		d.add("tracer", checkSkip, "tracing is disabled", "")
+		return
+	}
+
+	tracerURL := d.cli.Getenv("CC_TRACER_URL")
+	if tracerURL == "" {
+		tracerURL = "localhost:14268"
+	}
+	dialer := &net.Dialer{Timeout: 3 * time.Second}
+	conn, err := dialer.DialContext(d.ctx, "tcp", tracerURL)
+	if err != nil {
+		d.add("tracer", checkFail, err.Error(),
+			"start the Jaeger collector, set CC_TRACER_URL to its host:port, or unset CC_TRACING_ENABLED")
+		return
+	}
+	conn.Close()
+	d.add("tracer", checkPass, tracerURL+" is reachable", "")
+}
+
+// checkSchema calls the read endpoints with strict decoding and reports the drift of each.
+// The endpoints needing a scope the token lacks are skipped, the scopes check already warned about them.
+func (d *doctor) checkSchema() {
+	ccClient := d.client
+
+	// lacks skips the checks when the token lacks the scope.
+	lacks := func(scope cc.Scope, operations ...string) bool {
+		if ccClient.RequireScopes(scope) == nil {
+			return false
+		}
+		for _, operation := range operations {
+			d.skip("needs the "+string(scope)+" scope", "schema "+operation)
+		}
+		return true
+	}
+
+	check := func(operation string, err error) {
+		name := "schema " + operation
+		if err != nil {
+			d.add(name, checkFail, err.Error(), "")
+			return
+		}
+		if drift, found := d.drifts[operation]; found {
+			d.add(name, checkFail, drift.Details(),
+				"the API changed: update cc-ctl, or report the drift at https://github.com/camunda-community-hub/camunda-cloud-go-client/issues")
+			return
+		}
+		d.add(name, checkPass, "", "")
+	}
+
+	check("login", nil)
+	check("getClusterParams", nil)
+
+	if !lacks(cc.ScopeCluster, "getClusters", "getClusterDetails", "getZeebeClients", "getZeebeClientDetails") {
+		clusters, err := ccClient.GetClustersWithContext(d.ctx)
+		check("getClusters", err)
+
+		if len(clusters) == 0 {
+			d.skip("no cluster", "schema getClusterDetails", "schema getZeebeClients", "schema getZeebeClientDetails")
+		} else {
+			_, err = ccClient.GetClusterDetailsWithContext(d.ctx, clusters[0].ID)
+			check("getClusterDetails", err)
+			d.checkZeebeClientsSchema(clusters[0], check, lacks)
+		}
+	}
+
+	if !lacks(cc.ScopeMembers, "getMembers") {
+		_, err := ccClient.GetMembersWithContext(d.ctx)
+		check("getMembers", err)
+	}
+}
+
+func (d *doctor) checkZeebeClientsSchema(cluster cc.Cluster, check func(string, error), lacks func(cc.Scope, ...string) bool) {
+	if lacks(cc.ScopeZeebeClient, "getZeebeClients", "getZeebeClientDetails") {
+		return
+	}
+	zeebeClients, err := d.client.GetZeebeClientsWithContext(d.ctx, cluster.ID)
+	check("getZeebeClients", err)
+
+	if len(zeebeClients) == 0 {
+		d.skip("no zeebe client in cluster "+cluster.Name, "schema getZeebeClientDetails")
+		return
+	}
+	_, err = d.client.GetZeebeClientDetailsWithContext(d.ctx, cluster.ID, zeebeClients[0].ClientID)
+	check("getZeebeClientDetails", err)
+}
+
+// printDoctorChecks prints a line per check, with the hint under failed ones, and a summary.
+func printDoctorChecks(out io.Writer, checks []doctorCheck) {
+	width := 0
+	for _, check := range checks {
+		if len(check.Name) > width {
+			width = len(check.Name)
+		}
+	}
+
+	failed := 0
+	for _, check := range checks {
+		line := fmt.Sprintf("%-4s  %-*s  %s", strings.ToUpper(check.Status), width, check.Name, check.Details)
+		fmt.Fprintln(out, strings.TrimRight(line, " "))
+		if check.Hint != "" {
+			fmt.Fprintf(out, "      %-*s  hint: %s\n", width, "", check.Hint)
+		}
+		if check.Status == checkFail {
+			failed++
+		}
+	}
+
+	if failed == 0 {
+		fmt.Fprintln(out, "\nAll checks passed.")
+	} else {
+		fmt.Fprintf(out, "\n%d of %d checks failed.\n", failed, len(checks))
+	}
+}
@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/camunda-community-hub/camunda-cloud-go-client/pkg/cc/cctest"
+	cc "github.com/camunda-community-hub/camunda-cloud-go-client/pkg/cc/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Doctor(t *testing.T) {
+	srv := newTestServer(t)
+
+	result := runCLI(t, srv, "doctor")
+
+	assert.Equal(t, 0, result.ExitCode, result.Stderr)
+	assertGolden(t, "doctor", result.Stdout)
+}
+
+func Test_Doctor_unauthorized(t *testing.T) {
+	srv := newTestServer(t)
+	srv.ClientID, srv.ClientSecret = "id", "secret"
+
+	result := runCLI(t, srv, "doctor")
+
+	assert.Equal(t, 1, result.ExitCode)
+	assert.Equal(t, "Error: some checks failed\n", result.Stderr)
+	assertGolden(t, "doctor_unauthorized", result.Stdout)
+}
+
+func Test_Doctor_tokenExpiry(t *testing.T) {
+	for name, test := range map[string]struct {
+		serverClock time.Duration
+		want        string
+	}{
+		"expired":        {serverClock: -2 * time.Hour, want: "FAIL  token expiry    the token expired at 2021-03-05T09:00:00Z by the clock of this machine\n"},
+		"clock behind":   {serverClock: time.Hour, want: "FAIL  token expiry    expires in 2h0m0s by the clock of this machine, 1h0m0s according to the login server\n"},
+		"slightly ahead": {serverClock: -10 * time.Second, want: "PASS  token expiry    expires in 59m50s\n"},
+	} {
+		srv := newTestServer(t)
+		srv.Now = func() time.Time { return testNow().Add(test.serverClock) }
+
+		result := runCLI(t, srv, "doctor")
+
+		assert.Contains(t, result.Stdout, test.want, name)
+	}
+}
+
+func Test_tokenExpiry(t *testing.T) {
+	// {"alg":"none"}.{"exp":1614938400}.
+	expiry, ok := tokenExpiry("eyJhbGciOiJub25lIn0.eyJleHAiOjE2MTQ5Mzg0MDB9.")
+	assert.True(t, ok)
+	assert.Equal(t, testNow(), expiry.UTC())
+
+	for _, token := range []string{"", "opaque", "a.b.c", "eyJhbGciOiJub25lIn0.eyJzdWIiOiJ4In0."} {
+		_, ok := tokenExpiry(token)
+		assert.False(t, ok, token)
+	}
+}
+
+func Test_Doctor_json(t *testing.T) {
+	srv := newTestServer(t)
+	srv.Scope = "Cluster"
+
+	result := runCLI(t, srv, "doctor", "-o", "json")
+
+	assert.Equal(t, 0, result.ExitCode, "missing scopes are warnings")
+	assertGolden(t, "doctor.json", result.Stdout)
+}
+
+func Test_Doctor_noScope(t *testing.T) {
+	srv := newTestServer(t)
+	srv.Scope = ""
+
+	result := runCLI(t, srv, "doctor")
+
+	assert.Equal(t, 0, result.ExitCode, "scopes are not checked, as by the client")
+	assert.Contains(t, result.Stdout, "WARN  scopes          the token does not list its scopes, they are not checked\n")
+}
+
+func Test_DoctorSchema_missingScope(t *testing.T) {
+	srv := newTestServer(t)
+	srv.Scope = "Cluster ZeebeClient"
+
+	result := runCLI(t, srv, "doctor", "--schema")
+
+	assert.Equal(t, 0, result.ExitCode, result.Stdout)
+	assert.Contains(t, result.Stdout, "SKIP  schema getMembers")
+	assert.Contains(t, result.Stdout, "needs the Members scope\n")
+	assert.Empty(t, srv.RequestsTo("GET /members"))
+}
+
+func Test_DoctorSchema(t *testing.T) {
+	srv := newTestServer(t)
+	cluster := srv.AddCluster(cc.Cluster{Name: "orders"})
+	_, err := srv.AddZeebeClient(cluster.ID, "worker")
+	assert.NoError(t, err)
+	srv.InjectFault("GET /clusters", cctest.Fault{Status: 200, Body: `[{"uuid": "` + cluster.ID + `", "name": "orders", "console": "https://console"}]`})
+
+	result := runCLI(t, srv, "doctor", "--schema")
+
+	assert.Equal(t, 1, result.ExitCode)
+	assertGolden(t, "doctor_schema_drift", result.Stdout)
+}
@@ -0,0 +1,283 @@
+/*
+Copyright © 2021
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"time"
+
+	cc "github.com/camunda-community-hub/camunda-cloud-go-client/pkg/cc/client"
+	"github.com/spf13/cobra"
+)
+
+var ephemeralRunExample = `
+
+  # Run the integration tests against a new development cluster, deleted afterwards
+  cc-ctl ephemeral run --plan Development --name-prefix ci- -- make integration-test
+
+  # Keep the cluster when the tests fail, to look at it
+  cc-ctl ephemeral run --keep-on-failure -- make integration-test`
+
+// ephemeralFlags are the flags of the ephemeral run command.
+type ephemeralFlags struct {
+	namePrefix    string
+	plan          string
+	channel       string
+	generation    string
+	region        string
+	scopeNames    []string
+	timeout       time.Duration
+	readyTimeout  time.Duration
+	pollInterval  time.Duration
+	keepOnFailure bool
+}
+
+func CreateEphemeralCmd(cli *CLI) *cobra.Command {
+	ephemeralCmd := &cobra.Command{
+		Use:   "ephemeral",
+		Short: "Run commands against throwaway clusters",
+		Long:  "Used together with run, to run a command against a cluster that only lives as long as the command. For example:" + ephemeralRunExample,
+	}
+
+	ephemeralCmd.AddCommand(CreateEphemeralRunCmd(cli))
+
+	return ephemeralCmd
+}
+
+func CreateEphemeralRunCmd(cli *CLI) *cobra.Command {
+	flags := &ephemeralFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "run [flags] -- command [args...]",
+		Short: "Run a command against a new cluster, deleted afterwards",
+		Long: `Creates a uniquely named cluster, waits for Zeebe to be healthy, creates a Zeebe client and runs the command
+with ZEEBE_ADDRESS, ZEEBE_CLIENT_ID, ZEEBE_CLIENT_SECRET, ZEEBE_AUTHORIZATION_SERVER_URL, CC_CLUSTER_ID
+and CC_CLUSTER_NAME in its environment. The cluster is deleted when the command exits, fails or times out,
+and when cc-ctl receives SIGINT or SIGTERM, which are passed on to the command.
+cc-ctl exits with the exit code of the command. For example:` + ephemeralRunExample,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			scopes, err := cc.ParseZeebeClientScopes(flags.scopeNames)
+			if err != nil {
+				return err
+			}
+
+			err = (&ephemeralRun{cli: cli, flags: flags, scopes: scopes}).run(cmd.Context(), args)
+
+			if exit := (&exitError{}); errors.As(err, &exit) {
+				// The command already told what went wrong.
+				cmd.SilenceErrors = true
+			}
+			return err
+		},
+	}
+
+	// Flags after the command name belong to the command.
+	cmd.Flags().SetInterspersed(false)
+
+	cmd.Flags().StringVar(&flags.namePrefix, "name-prefix", "ephemeral-", "Prefix of the name of the cluster, followed by the time and a random suffix")
+	cmd.Flags().StringVar(&flags.plan, "plan", "Development", "Cluster's plan type name")
+	cmd.Flags().StringVar(&flags.channel, "channel", "", "Cluster's channel name (default Stable)")
+	cmd.Flags().StringVar(&flags.generation, "generation", "", "Cluster's generation name (default the one of the channel)")
+	cmd.Flags().StringVar(&flags.region, "region", "", "Cluster's region name (default the first one)")
+	cmd.Flags().StringSliceVarP(&flags.scopeNames, "scopes", "s", nil, scopesFlagUsage())
+	cmd.Flags().DurationVar(&flags.timeout, "timeout", 0, "Maximum duration of the whole run, the cluster is deleted when it is reached (default none)")
+	cmd.Flags().DurationVar(&flags.readyTimeout, "ready-timeout", 20*time.Minute, "Maximum time to wait for the cluster to be healthy")
+	cmd.Flags().DurationVar(&flags.pollInterval, "poll-interval", cc.DefaultPollInterval, "Time between two checks of the cluster status")
+	cmd.Flags().BoolVar(&flags.keepOnFailure, "keep-on-failure", false, "Keep the cluster when the command fails or times out, to debug it")
+
+	return cmd
+}
+
+// ephemeralRun is a run of a command against an ephemeral cluster.
+type ephemeralRun struct {
+	cli    *CLI
+	flags  *ephemeralFlags
+	scopes []cc.ZeebeClientScope
+}
+
+func (r *ephemeralRun) run(ctx context.Context, args []string) (err error) {
+	if r.flags.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.flags.timeout)
+		defer cancel()
+	}
+
+	// Fail before creating a cluster that would be waited for in vain.
+	if err := r.cli.Client.RequireScopes(cc.ScopeCluster, cc.ScopeZeebeClient); err != nil {
+		return err
+	}
+
+	name := r.flags.namePrefix + r.cli.Now().UTC().Format("20060102-150405") + "-" + randomSuffix()
+	r.printf("Creating cluster %s\n", name)
+	clusterID, err := r.cli.Client.CreateClusterFromSpec(ctx, cc.ClusterSpec{Name: name,
+		Plan: r.flags.plan, Channel: r.flags.channel, Generation: r.flags.generation, Region: r.flags.region})
+	if err != nil {
+		if ctx.Err() != nil {
+			r.cleanupUnconfirmed(name)
+		}
+		return r.explain(ctx, err)
+	}
+
+	defer func() {
+		if cleanupErr := r.cleanup(clusterID, name, err != nil, ctx.Err() == context.Canceled); cleanupErr != nil && err == nil {
+			err = cleanupErr
+		}
+	}()
+
+	env, err := r.prepare(ctx, clusterID, name)
+	if err != nil {
+		return r.explain(ctx, err)
+	}
+
+	r.printf("Running %v\n", args)
+	return r.explain(ctx, r.runCommand(ctx, args, env))
+}
+
+// prepare waits for the cluster, creates a Zeebe client and returns the environment of the command.
+func (r *ephemeralRun) prepare(ctx context.Context, clusterID string, name string) ([]string, error) {
+	r.printf("Waiting for cluster %s to be healthy\n", clusterID)
+	readyCtx, cancel := context.WithTimeout(ctx, r.flags.readyTimeout)
+	defer cancel()
+	if _, err := r.cli.Client.WaitForClusterHealthy(readyCtx, clusterID, r.flags.pollInterval); err != nil {
+		if errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil {
+			return nil, fmt.Errorf("cluster %s was not healthy after %s", clusterID, r.flags.readyTimeout)
+		}
+		return nil, err
+	}
+
+	created, err := r.cli.Client.CreateZeebeClientWithContext(ctx, clusterID, name, r.scopes...)
+	if err != nil {
+		return nil, err
+	}
+	details, err := r.cli.Client.GetZeebeClientDetailsWithContext(ctx, clusterID, created.ClientID)
+	if err != nil {
+		return nil, err
+	}
+	r.printf("Created Zeebe client %s\n", created.ClientID)
+
+	return append(os.Environ(),
+		"ZEEBE_ADDRESS="+details.ZEEBEADDRESS,
+		"ZEEBE_CLIENT_ID="+created.ClientID,
+		"ZEEBE_CLIENT_SECRET="+created.ClientSecret,
+		"ZEEBE_AUTHORIZATION_SERVER_URL="+details.ZEEBEAUTHORIZATIONSERVERURL,
+		"CC_CLUSTER_ID="+clusterID,
+		"CC_CLUSTER_NAME="+name,
+	), nil
+}
+
+// runCommand streams the output of the command. The signals received are passed on to it,
+// and it is killed when the context is done otherwise.
+func (r *ephemeralRun) runCommand(ctx context.Context, args []string, env []string) error {
+	command := exec.Command(args[0], args[1:]...)
+	command.Env = env
+	command.Stdin = r.cli.In
+	command.Stdout = r.cli.Out
+	command.Stderr = r.cli.ErrOut
+	if err := command.Start(); err != nil {
+		return err
+	}
+
+	exited := make(chan struct{})
+	defer close(exited)
+	go func() {
+		select {
+		case <-exited:
+		case <-ctx.Done():
+			if sig := r.cli.interruptSignal(); sig != nil {
+				command.Process.Signal(sig)
+			} else {
+				command.Process.Kill()
+			}
+		}
+	}()
+
+	err := command.Wait()
+	exitErr := &exec.ExitError{}
+	if errors.As(err, &exitErr) {
+		code := exitErr.ExitCode()
+		if code < 0 {
+			// Killed by a signal.
+			code = 1
+		}
+		return &exitError{code: code}
+	}
+	return err
+}
+
+// cleanup deletes the cluster, unless the run failed and the cluster should be kept.
+// Clusters are always deleted when the run is canceled, for example by a signal.
+func (r *ephemeralRun) cleanup(clusterID string, name string, failed bool, canceled bool) error {
+	if failed && r.flags.keepOnFailure && !canceled {
+		r.printf("Keeping cluster %s (%s), delete it with: cc-ctl clusters delete --id %s\n", name, clusterID, clusterID)
+		return nil
+	}
+
+	r.printf("Deleting cluster %s\n", clusterID)
+	ctx, cancel := context.WithTimeout(context.Background(), cleanupTimeout)
+	defer cancel()
+	if _, err := r.cli.Client.DeleteClusterWithContext(ctx, clusterID); err != nil {
+		r.printf("Failed to delete cluster %s (%s): %v\n", name, clusterID, err)
+		return fmt.Errorf("failed to delete cluster %s: %v", clusterID, err)
+	}
+	return nil
+}
+
+// cleanupUnconfirmed deletes the cluster named name when its creation was canceled while the request
+// was in flight, in which case Camunda Cloud may have created it anyway.
+func (r *ephemeralRun) cleanupUnconfirmed(name string) {
+	ctx, cancel := context.WithTimeout(context.Background(), cleanupTimeout)
+	defer cancel()
+	cluster, err := r.cli.Client.GetClusterByNameWithContext(ctx, name)
+	if err != nil {
+		r.printf("Failed to check whether cluster %s was created: %v\n", name, err)
+		return
+	}
+	if cluster.ID != "" {
+		r.cleanup(cluster.ID, name, true, true)
+	}
+}
+
+// explain replaces the errors caused by the end of the context with the reason it ended.
+func (r *ephemeralRun) explain(ctx context.Context, err error) error {
+	if err == nil || ctx.Err() == nil {
+		return err
+	}
+	if sig := r.cli.interruptSignal(); sig != nil {
+		return fmt.Errorf("interrupted by %s", sig)
+	}
+	if ctx.Err() == context.DeadlineExceeded {
+		return fmt.Errorf("timed out after %s", r.flags.timeout)
+	}
+	return fmt.Errorf("canceled: %v", err)
+}
+
+func (r *ephemeralRun) printf(format string, args ...interface{}) {
+	fmt.Fprintf(r.cli.ErrOut, format, args...)
+}
+
+// randomSuffix makes the names of clusters created in the same second different.
+func randomSuffix() string {
+	suffix := make([]byte, 2)
+	rand.Read(suffix)
+	return hex.EncodeToString(suffix)
+}
@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/camunda-community-hub/camunda-cloud-go-client/pkg/cc/cctest"
+	cc "github.com/camunda-community-hub/camunda-cloud-go-client/pkg/cc/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_EphemeralRun(t *testing.T) {
+	srv := newTestServer(t)
+
+	result := runCLI(t, srv, "ephemeral", "run", "--name-prefix", "ci-", "--poll-interval", "1ms", "--",
+		"sh", "-c", `echo "$ZEEBE_ADDRESS $ZEEBE_CLIENT_ID $ZEEBE_CLIENT_SECRET $CC_CLUSTER_NAME"`)
+
+	assert.Equal(t, 0, result.ExitCode, result.Stderr)
+	assert.Regexp(t, `^00000000-0000-4000-8000-000000000001\.region-\S+\.zeebe\.camunda\.io:443 cctest-client-\d+ cctest-secret-\d+ ci-20210305-100000-[0-9a-f]{4}\n$`, result.Stdout)
+	assert.Regexp(t, `^Creating cluster ci-20210305-100000-[0-9a-f]{4}
+Waiting for cluster 00000000-0000-4000-8000-000000000001 to be healthy
+Created Zeebe client cctest-client-\d+
+Running \[sh -c .*\]
+Deleting cluster 00000000-0000-4000-8000-000000000001
+$`, result.Stderr)
+	requests := srv.RequestsTo("POST /clusters")
+	if assert.Len(t, requests, 1) {
+		assert.Contains(t, string(requests[0].Body), `"planTypeId":"plan-development"`)
+	}
+	assert.Len(t, srv.RequestsTo("DELETE /clusters/{clusterId}"), 1)
+}
+
+func Test_EphemeralRun_commandFails(t *testing.T) {
+	srv := newTestServer(t)
+
+	result := runCLI(t, srv, "ephemeral", "run", "--poll-interval", "1ms", "--", "sh", "-c", "exit 3")
+
+	assert.Equal(t, 3, result.ExitCode)
+	assert.NotContains(t, result.Stderr, "Error:")
+	assert.Len(t, srv.RequestsTo("DELETE /clusters/{clusterId}"), 1)
+
+	result = runCLI(t, srv, "ephemeral", "run", "--poll-interval", "1ms", "--keep-on-failure", "--", "sh", "-c", "exit 3")
+
+	assert.Equal(t, 3, result.ExitCode)
+	assert.Contains(t, result.Stderr, "Keeping cluster ephemeral-20210305-100000-")
+	assert.Contains(t, result.Stderr, "delete it with: cc-ctl clusters delete --id 00000000-0000-4000-8000-000000000003\n")
+	assert.Len(t, srv.RequestsTo("DELETE /clusters/{clusterId}"), 1, "the second cluster is kept")
+}
+
+func Test_EphemeralRun_timeout(t *testing.T) {
+	srv := newTestServer(t)
+
+	result := runCLI(t, srv, "ephemeral", "run", "--poll-interval", "1ms", "--timeout", "200ms", "--", "sleep", "10")
+
+	assert.Equal(t, 1, result.ExitCode)
+	assert.Contains(t, result.Stderr, "Error: timed out after 200ms\n")
+	assert.Len(t, srv.RequestsTo("DELETE /clusters/{clusterId}"), 1)
+}
+
+func Test_EphemeralRun_signals(t *testing.T) {
+	for _, signal := range []struct {
+		name     string
+		exitCode int
+	}{{"INT", 130}, {"TERM", 143}} {
+		srv := newTestServer(t)
+
+		// The command signals cc-ctl, its parent, as a Ctrl-C or a CI runner stopping the job would.
+		result := runCLI(t, srv, "ephemeral", "run", "--poll-interval", "1ms", "--",
+			"sh", "-c", "kill -"+signal.name+" $PPID && exec sleep 10")
+
+		assert.Equal(t, signal.exitCode, result.ExitCode, signal.name)
+		assert.Contains(t, result.Stderr, "Deleting cluster 00000000-0000-4000-8000-000000000001\n", signal.name)
+		assert.Len(t, srv.RequestsTo("DELETE /clusters/{clusterId}"), 1, signal.name)
+	}
+}
+
+func Test_EphemeralRun_canceledWhileCreating(t *testing.T) {
+	srv := newTestServer(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client := &cc.CCClient{}
+	// Camunda Cloud creates the cluster, but the run is canceled before the answer arrives.
+	client.Use(func(info cc.RequestInfo, req *http.Request, next cc.Next) (*http.Response, error) {
+		if info.Operation != "createCluster" {
+			return next(req)
+		}
+		resp, err := next(req)
+		if err == nil {
+			resp.Body.Close()
+		}
+		cancel()
+		return nil, context.Canceled
+	})
+
+	result := runCLIClient(t, srv, ctx, client, "ephemeral", "run", "--poll-interval", "1ms", "--", "true")
+
+	assert.Equal(t, 1, result.ExitCode)
+	assert.Contains(t, result.Stderr, "Deleting cluster 00000000-0000-4000-8000-000000000001\n")
+	assert.Len(t, srv.RequestsTo("DELETE /clusters/{clusterId}"), 1)
+}
+
+func Test_EphemeralRun_checksBeforeCreating(t *testing.T) {
+	srv := newTestServer(t)
+	srv.Scope = "Cluster"
+
+	result := runCLI(t, srv, "ephemeral", "run", "--", "true")
+
+	assert.Equal(t, 1, result.ExitCode)
+	assert.Contains(t, result.Stderr, "Error: missing scope: requireScopes needs the ZeebeClient scope")
+
+	srv.Scope = cctest.DefaultScope()
+	result = runCLI(t, srv, "ephemeral", "run", "--plan", "Enterprise", "--", "true")
+
+	assert.Equal(t, 1, result.ExitCode)
+	assert.Contains(t, result.Stderr, "Error: No plan found with name or id: Enterprise\n")
+	assert.Empty(t, srv.RequestsTo("POST /clusters"))
+}
@@ -0,0 +1,241 @@
+/*
+Copyright © 2021
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	cc "github.com/camunda-community-hub/camunda-cloud-go-client/pkg/cc/client"
+	"github.com/spf13/cobra"
+)
+
+var fleetUpgradeExample = `
+
+  # Upgrade the clusters of the Stable channel to its newest generation, 3 at a time, 10 minutes apart
+  cc-ctl fleet upgrade --selector channel=Stable --to latest --wave-size 3 --pause 10m
+
+  # Show what would be upgraded to the recommended generation
+  cc-ctl fleet upgrade --selector 'channel=Stable,name=prod-*' --to recommended --dry-run
+
+  # Resume an interrupted or halted rollout
+  cc-ctl fleet upgrade --selector channel=Stable --to latest --wave-size 3 --pause 10m`
+
+// selectorKeys are the keys of --selector, with what they filter.
+var selectorKeys = map[string]func(opts *cc.ListOptions, value string){
+	"name":       func(opts *cc.ListOptions, value string) { opts.Name, opts.NameMatch = value, cc.NameMatchGlob },
+	"channel":    func(opts *cc.ListOptions, value string) { opts.Channel = value },
+	"generation": func(opts *cc.ListOptions, value string) { opts.Generation = value },
+	"plan":       func(opts *cc.ListOptions, value string) { opts.Plan = value },
+	"region":     func(opts *cc.ListOptions, value string) { opts.Region = value },
+}
+
+// fleetUpgradeFlags are the flags of the fleet upgrade command.
+type fleetUpgradeFlags struct {
+	selector       string
+	to             string
+	stateFile      string
+	dryRun         bool
+	rolloutOptions cc.RolloutOptions
+}
+
+// fleetState is the state file of fleet upgrade: the rollout and the selector it was planned with.
+type fleetState struct {
+	Selector string `json:"selector"`
+	cc.RolloutState
+}
+
+func CreateFleetCmd(cli *CLI) *cobra.Command {
+	fleetCmd := &cobra.Command{
+		Use:   "fleet",
+		Short: "Operate on many clusters at once",
+		Long:  "Used together with upgrade, to roll a generation out to the clusters matching a selector. For example:" + fleetUpgradeExample,
+	}
+
+	fleetCmd.AddCommand(CreateFleetUpgradeCmd(cli))
+
+	return fleetCmd
+}
+
+func CreateFleetUpgradeCmd(cli *CLI) *cobra.Command {
+	flags := &fleetUpgradeFlags{}
+
+	upgradeCmd := &cobra.Command{
+		Use:   "upgrade",
+		Short: "Upgrade the generation of the clusters matching a selector in waves",
+		Long: `Upgrades the clusters matching the selector to a generation of their channel, a wave of clusters at a time.
+Before the next wave, Zeebe, Operate and Tasklist of every cluster of the wave have to be healthy on the new generation.
+The rollout halts after a wave in which a cluster failed to upgrade or to get healthy in time.
+
+The selector is a comma separated list of key=value, with key channel, generation, plan, region (name or id)
+or name (glob pattern). The target is latest for the newest generation of each channel, recommended for its
+default generation, or a generation name or id.
+
+The rollout is saved to the state file after every change. Running the command again with a state file of an
+unfinished rollout resumes it: the clusters that failed or were upgrading are upgraded again. For example:` + fleetUpgradeExample,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			filter, err := parseSelector(flags.selector)
+			if err != nil {
+				return err
+			}
+
+			state, resumed, err := loadFleetState(flags.stateFile)
+			if err != nil {
+				return err
+			}
+			if resumed && (state.Selector != flags.selector || state.Target != flags.to) {
+				return fmt.Errorf("%s holds an unfinished rollout of %s to clusters matching %q: run it with the same --selector and --to, or remove the file",
+					flags.stateFile, state.Target, state.Selector)
+			}
+			if !resumed {
+				planned, err := cli.Client.PlanRollout(cmd.Context(), filter, flags.to)
+				if err != nil {
+					return err
+				}
+				state = fleetState{Selector: flags.selector, RolloutState: planned}
+			}
+
+			if flags.dryRun {
+				return showRollout(cli.Out, state.RolloutState)
+			}
+
+			if resumed {
+				fmt.Fprintf(cli.Out, "Resuming the rollout of %s from %s: %d done, %d left\n", state.Target, flags.stateFile,
+					state.Count(cc.RolloutDone), state.Count(cc.RolloutPending)+state.Count(cc.RolloutUpgrading)+state.Count(cc.RolloutFailed))
+			} else {
+				fmt.Fprintf(cli.Out, "Rolling %s out to %d of %d clusters, saving the rollout to %s\n",
+					state.Target, state.Count(cc.RolloutPending), len(state.Clusters), flags.stateFile)
+			}
+			if err := saveFleetState(flags.stateFile, state); err != nil {
+				return err
+			}
+
+			opts := flags.rolloutOptions
+			opts.Progress = func(rollout cc.RolloutState, cluster cc.RolloutCluster) error {
+				showRolloutProgress(cli.Out, cluster)
+				return saveFleetState(flags.stateFile, fleetState{Selector: state.Selector, RolloutState: rollout})
+			}
+			err = cli.Client.RolloutGeneration(cmd.Context(), &state.RolloutState, opts)
+
+			fmt.Fprintln(cli.Out)
+			if showErr := showRollout(cli.Out, state.RolloutState); showErr != nil && err == nil {
+				err = showErr
+			}
+			if err == nil {
+				err = state.Err()
+			}
+			if err != nil {
+				return fmt.Errorf("%w, run the command again to resume from %s", err, flags.stateFile)
+			}
+			return nil
+		},
+	}
+
+	upgradeCmd.Flags().StringVar(&flags.selector, "selector", "", "Clusters to upgrade, for example channel=Stable,plan=Production - S,name=prod-*")
+	upgradeCmd.Flags().StringVar(&flags.to, "to", cc.RolloutToLatest, "Target generation: latest, recommended or a generation name or id")
+	upgradeCmd.Flags().StringVar(&flags.stateFile, "state-file", "fleet-upgrade.json", "File the rollout is saved to, and resumed from")
+	upgradeCmd.Flags().BoolVar(&flags.dryRun, "dry-run", false, "Show the clusters that would be upgraded without upgrading them")
+	upgradeCmd.Flags().IntVar(&flags.rolloutOptions.WaveSize, "wave-size", 1, "Number of clusters upgraded at the same time")
+	upgradeCmd.Flags().DurationVar(&flags.rolloutOptions.Pause, "pause", 0, "Time to wait between two waves")
+	upgradeCmd.Flags().DurationVar(&flags.rolloutOptions.HealthTimeout, "health-timeout", 30*time.Minute, "Maximum time for an upgraded cluster to be healthy")
+	upgradeCmd.Flags().DurationVar(&flags.rolloutOptions.PollInterval, "poll-interval", cc.DefaultPollInterval, "Time between two checks of the cluster status")
+	upgradeCmd.Flags().DurationVar(&flags.rolloutOptions.UpgradeStartGrace, "upgrade-start-grace", time.Minute, "Time after which an upgraded cluster still healthy on its target is taken as upgraded")
+	upgradeCmd.MarkFlagRequired("selector")
+
+	return upgradeCmd
+}
+
+// parseSelector turns key=value pairs separated by commas into list options.
+func parseSelector(selector string) (cc.ListOptions, error) {
+	opts := cc.ListOptions{SortBy: cc.SortByName}
+	for _, pair := range strings.Split(selector, ",") {
+		parts := strings.SplitN(pair, "=", 2)
+		set, known := selectorKeys[strings.TrimSpace(parts[0])]
+		if len(parts) != 2 || !known || strings.TrimSpace(parts[1]) == "" {
+			return opts, fmt.Errorf("invalid selector %q: want key=value with key channel, generation, plan, region or name", pair)
+		}
+		set(&opts, strings.TrimSpace(parts[1]))
+	}
+	return opts, nil
+}
+
+// loadFleetState returns the rollout of the state file when it is unfinished.
+func loadFleetState(path string) (fleetState, bool, error) {
+	state := fleetState{}
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return state, false, nil
+	}
+	if err != nil {
+		return state, false, err
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return state, false, fmt.Errorf("invalid state file %s: %v", path, err)
+	}
+	unfinished := state.Count(cc.RolloutPending) + state.Count(cc.RolloutUpgrading) + state.Count(cc.RolloutFailed)
+	return state, unfinished > 0, nil
+}
+
+// saveFleetState replaces the state file, so that an interruption never leaves half of it.
+func saveFleetState(path string, state fleetState) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(path+".tmp", data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(path+".tmp", path)
+}
+
+func showRolloutProgress(out io.Writer, cluster cc.RolloutCluster) {
+	switch cluster.Status {
+	case cc.RolloutUpgrading:
+		fmt.Fprintf(out, "Wave %d: upgrading %s from %s to %s\n", cluster.Wave, cluster.Name, cluster.From, cluster.To)
+	case cc.RolloutDone:
+		fmt.Fprintf(out, "Wave %d: %s is healthy on %s\n", cluster.Wave, cluster.Name, cluster.To)
+	case cc.RolloutFailed:
+		fmt.Fprintf(out, "Wave %d: %s failed: %s\n", cluster.Wave, cluster.Name, cluster.Error)
+	}
+}
+
+// showRollout prints a line per cluster of the rollout and a summary.
+func showRollout(out io.Writer, state cc.RolloutState) error {
+	w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "NAME\tFROM\tTO\tWAVE\tSTATUS\tDETAILS")
+	for _, cluster := range state.Clusters {
+		wave := "-"
+		if cluster.Wave > 0 {
+			wave = fmt.Sprint(cluster.Wave)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", cluster.Name, valueOrDash(cluster.From), valueOrDash(cluster.To),
+			wave, cluster.Status, valueOrDash(cluster.Reason+cluster.Error))
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "\n%d done, %d skipped, %d failed, %d left.\n", state.Count(cc.RolloutDone), state.Count(cc.RolloutSkipped),
+		state.Count(cc.RolloutFailed), state.Count(cc.RolloutPending)+state.Count(cc.RolloutUpgrading))
+	return nil
+}
@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/camunda-community-hub/camunda-cloud-go-client/pkg/cc/cctest"
+	cc "github.com/camunda-community-hub/camunda-cloud-go-client/pkg/cc/client"
+	"github.com/stretchr/testify/assert"
+)
+
+// addFleet adds clusters a, b and c on the oldest generation of the Stable channel,
+// current on its newest and alpha on the Alpha channel.
+func addFleet(srv *cctest.Server) {
+	stable, alpha := srv.Params().Channels[0], srv.Params().Channels[1]
+	for _, name := range []string{"a", "b", "c"} {
+		srv.AddCluster(cc.Cluster{Name: name, Channel: stable, Generation: stable.AllowedGeneration[0]})
+	}
+	srv.AddCluster(cc.Cluster{Name: "current", Channel: stable, Generation: stable.DefaultGeneration})
+	srv.AddCluster(cc.Cluster{Name: "alpha", Channel: alpha, Generation: alpha.DefaultGeneration})
+}
+
+func readFleetState(t *testing.T, path string) fleetState {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	state := fleetState{}
+	if err := json.Unmarshal(data, &state); err != nil {
+		t.Fatal(err)
+	}
+	return state
+}
+
+// replacePath replaces the temporary state file path in outputs, to compare them with goldens.
+func replacePath(output string, path string) string {
+	return strings.ReplaceAll(output, path, filepath.Base(path))
+}
+
+func Test_FleetUpgrade(t *testing.T) {
+	srv := newTestServer(t)
+	addFleet(srv)
+	stateFile := filepath.Join(t.TempDir(), "rollout.json")
+
+	result := runCLI(t, srv, "fleet", "upgrade", "--selector", "channel=Stable", "--to", "latest", "--poll-interval", "1ms",
+		"--state-file", stateFile)
+
+	assert.Equal(t, 0, result.ExitCode, result.Stderr)
+	assertGolden(t, "fleet_upgrade", replacePath(result.Stdout, stateFile))
+	assert.Len(t, srv.RequestsTo("PUT /clusters/{clusterId}/generation"), 3)
+	state := readFleetState(t, stateFile)
+	assert.Equal(t, "channel=Stable", state.Selector)
+	assert.Equal(t, 3, state.Count(cc.RolloutDone))
+}
+
+func Test_FleetUpgrade_dryRun(t *testing.T) {
+	srv := newTestServer(t)
+	addFleet(srv)
+	stateFile := filepath.Join(t.TempDir(), "rollout.json")
+
+	result := runCLI(t, srv, "fleet", "upgrade", "--selector", "name=*", "--to", "recommended", "--dry-run", "--state-file", stateFile)
+
+	assert.Equal(t, 0, result.ExitCode, result.Stderr)
+	assertGolden(t, "fleet_upgrade_dry_run", result.Stdout)
+	assert.Empty(t, srv.RequestsTo("PUT /clusters/{clusterId}/generation"))
+	_, err := os.Stat(stateFile)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func Test_FleetUpgrade_haltsAndResumes(t *testing.T) {
+	srv := newTestServer(t)
+	addFleet(srv)
+	srv.InjectFault("PUT /clusters/{clusterId}/generation", cctest.Fail(500, 1))
+	stateFile := filepath.Join(t.TempDir(), "rollout.json")
+	args := []string{"fleet", "upgrade", "--selector", "channel=Stable", "--poll-interval", "1ms", "--state-file", stateFile}
+
+	result := runCLI(t, srv, args...)
+
+	assert.Equal(t, 1, result.ExitCode)
+	assert.Equal(t, "Error: rollout halted, failed to upgrade 1 clusters with 2 left: a: HTTP Error trying to upgradeCluster: 500, "+
+		"run the command again to resume from "+stateFile+"\n", result.Stderr)
+	assert.Equal(t, cc.RolloutFailed, readFleetState(t, stateFile).Clusters[0].Status)
+
+	result = runCLI(t, srv, "fleet", "upgrade", "--selector", "channel=Stable", "--to", "recommended", "--state-file", stateFile)
+	assert.Equal(t, cliResult{Stderr: "Error: " + stateFile + ` holds an unfinished rollout of latest to clusters matching "channel=Stable": ` +
+		"run it with the same --selector and --to, or remove the file\n", ExitCode: 1}, result)
+
+	result = runCLI(t, srv, args...)
+
+	assert.Equal(t, 0, result.ExitCode, result.Stderr)
+	assertGolden(t, "fleet_upgrade_resumed", replacePath(result.Stdout, stateFile))
+	assert.Equal(t, 3, readFleetState(t, stateFile).Count(cc.RolloutDone))
+}
+
+func Test_FleetUpgrade_invalidSelector(t *testing.T) {
+	srv := newTestServer(t)
+
+	result := runCLI(t, srv, "fleet", "upgrade", "--selector", "channel=Stable,color=blue")
+
+	assert.Equal(t, cliResult{Stderr: `Error: invalid selector "color=blue": want key=value with key channel, generation, plan, region or name` + "\n", ExitCode: 1}, result)
+}
@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/camunda-community-hub/camunda-cloud-go-client/pkg/cc/cctest"
+	cc "github.com/camunda-community-hub/camunda-cloud-go-client/pkg/cc/client"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+var update = flag.Bool("update", false, "update the golden files in testdata")
+
+// cliResult is what a cc-ctl run printed and exited with.
+type cliResult struct {
+	Stdout   string
+	Stderr   string
+	ExitCode int
+}
+
+// testNow is the fixed clock of the tests, so that outputs are stable.
+func testNow() time.Time {
+	return time.Date(2021, 3, 5, 10, 0, 0, 0, time.UTC)
+}
+
+// newTestServer starts a fake Camunda Cloud with the fixed clock of the tests.
+func newTestServer(t *testing.T) *cctest.Server {
+	srv := cctest.NewServer()
+	srv.Now = testNow
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// testServerURL replaces the URL of the test server, which changes with every run, in the outputs.
+const testServerURL = "http://cctest"
+
+// runCLI runs cc-ctl in-process with a context pointing at the server, and without
+// looking at the environment of the test.
+func runCLI(t *testing.T, srv *cctest.Server, args ...string) cliResult {
+	return runCLIContext(t, srv, context.Background(), args...)
+}
+
+// runCLIContext runs the command with ctx as base context, to cancel it as a signal would.
+func runCLIContext(t *testing.T, srv *cctest.Server, ctx context.Context, args ...string) cliResult {
+	return runCLIClient(t, srv, ctx, nil, args...)
+}
+
+// runCLIClient runs the command with the client, for example one with interceptors,
+// a new one when nil.
+func runCLIClient(t *testing.T, srv *cctest.Server, ctx context.Context, client cc.CCAPI, args ...string) cliResult {
+	config := viper.New()
+	config.Set("current-context", "cctest")
+	config.Set("contexts", map[string]interface{}{
+		"cctest": map[string]interface{}{
+			"client-id":     "cctest",
+			"client-secret": "cctest",
+			"api-url":       srv.URL,
+			"login-url":     srv.URL,
+		},
+	})
+
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	cli := &CLI{
+		IOStreams:   IOStreams{In: strings.NewReader(""), Out: stdout, ErrOut: stderr},
+		Client:      client,
+		Config:      config,
+		Getenv:      func(string) string { return "" },
+		Now:         testNow,
+		BaseContext: ctx,
+	}
+	exitCode := Run(cli, args)
+
+	return cliResult{
+		Stdout:   strings.ReplaceAll(stdout.String(), srv.URL, testServerURL),
+		Stderr:   strings.ReplaceAll(stderr.String(), srv.URL, testServerURL),
+		ExitCode: exitCode,
+	}
+}
+
+// assertGolden compares the output with testdata/<name>.golden. Run the tests with
+// -update to write the golden files instead.
+func assertGolden(t *testing.T, name string, actual string) {
+	t.Helper()
+	path := filepath.Join("testdata", name+".golden")
+
+	if *update {
+		if err := ioutil.WriteFile(path, []byte(actual), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	expected, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading golden file, run with -update to create it: %v", err)
+	}
+	assert.Equal(t, string(expected), actual)
+}
@@ -0,0 +1,180 @@
+/*
+Copyright © 2021
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	cc "github.com/camunda-community-hub/camunda-cloud-go-client/pkg/cc/client"
+	"github.com/spf13/cobra"
+)
+
+var (
+	membersGetExample = `
+
+  # List all organization members and pending invitations
+  cc-ctl members get`
+	membersInviteExample = `
+
+  # Invite a user into the organization
+  cc-ctl members invite --email=<email> --roles=developer,analyst`
+	membersUpdateExample = `
+
+  # Change the roles of a member
+  cc-ctl members update --email=<email> --roles=admin`
+	membersDeleteExample = `
+
+  # Remove a member from the organization
+  cc-ctl members delete --email=<email>`
+)
+
+// membersCmd represents the members command
+func CreateMembersCmd(cli *CLI) *cobra.Command {
+
+	membersCmd := &cobra.Command{
+		Use:   "members [options]",
+		Short: "Manage your organization members on Camunda Cloud",
+		Long: `Used together [OPTIONS] like get, invite, update, delete for manage your organization members on Camunda Cloud. For example:` +
+			membersGetExample + membersInviteExample + membersUpdateExample + membersDeleteExample,
+	}
+
+	membersCmd.AddCommand(CreateMembersGetCmd(cli))
+	membersCmd.AddCommand(CreateMembersInviteCmd(cli))
+	membersCmd.AddCommand(CreateMembersUpdateCmd(cli))
+	membersCmd.AddCommand(CreateMembersDeleteCmd(cli))
+
+	return membersCmd
+}
+
+func CreateMembersGetCmd(cli *CLI) *cobra.Command {
+	return &cobra.Command{
+		Use:   "get",
+		Short: "Get organization members",
+		Long:  "Used together with members command, to list your organization members on Camunda Cloud. For example:" + membersGetExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			members, err := cli.Client.GetMembersWithContext(cmd.Context())
+
+			if err != nil {
+				return err
+			}
+
+			return showJSON(cli.Out, members)
+		},
+	}
+}
+
+func CreateMembersInviteCmd(cli *CLI) *cobra.Command {
+	var memberEmail string
+	var roleNames []string
+
+	cmd := &cobra.Command{
+		Use:   "invite",
+		Short: "Invite a user into the organization",
+		Long:  "Used together with members command, to invite users into your organization on Camunda Cloud. For example:" + membersInviteExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			roles, err := cc.ParseMemberRoles(roleNames)
+
+			if err != nil {
+				return err
+			}
+
+			if _, err := cli.Client.InviteMemberWithContext(cmd.Context(), memberEmail, roles...); err != nil {
+				return err
+			}
+
+			fmt.Fprintln(cli.Out, "Invitation sent to", memberEmail)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&memberEmail, "email", "e", "", "Email of the user to invite")
+	cmd.Flags().StringSliceVarP(&roleNames, "roles", "r", nil, rolesFlagUsage())
+	cmd.MarkFlagRequired("email")
+	cmd.MarkFlagRequired("roles")
+
+	return cmd
+}
+
+func CreateMembersUpdateCmd(cli *CLI) *cobra.Command {
+	var memberEmail string
+	var roleNames []string
+
+	cmd := &cobra.Command{
+		Use:   "update",
+		Short: "Change the roles of a member",
+		Long:  "Used together with members command, to change the roles of a member on Camunda Cloud. For example:" + membersUpdateExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			roles, err := cc.ParseMemberRoles(roleNames)
+
+			if err != nil {
+				return err
+			}
+
+			if _, err := cli.Client.UpdateMemberRolesWithContext(cmd.Context(), memberEmail, roles...); err != nil {
+				return err
+			}
+
+			fmt.Fprintln(cli.Out, "Member updated successfully")
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&memberEmail, "email", "e", "", "Email of the member")
+	cmd.Flags().StringSliceVarP(&roleNames, "roles", "r", nil, rolesFlagUsage())
+	cmd.MarkFlagRequired("email")
+	cmd.MarkFlagRequired("roles")
+
+	return cmd
+}
+
+func CreateMembersDeleteCmd(cli *CLI) *cobra.Command {
+	var memberEmail string
+
+	cmd := &cobra.Command{
+		Use:   "delete",
+		Short: "Remove a member from the organization",
+		Long:  "Used together with members command, to remove members from your organization on Camunda Cloud. For example:" + membersDeleteExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			if _, err := cli.Client.DeleteMemberWithContext(cmd.Context(), memberEmail); err != nil {
+				return err
+			}
+
+			fmt.Fprintln(cli.Out, "Member deleted successfully")
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&memberEmail, "email", "e", "", "Email of the member")
+	cmd.MarkFlagRequired("email")
+
+	return cmd
+}
+
+func rolesFlagUsage() string {
+	names := []string{}
+	for _, role := range cc.MemberRoles {
+		names = append(names, string(role))
+	}
+	return "Comma separated organization roles (" + strings.Join(names, ",") + ")"
+}
@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"testing"
+
+	cc "github.com/camunda-community-hub/camunda-cloud-go-client/pkg/cc/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Members(t *testing.T) {
+	srv := newTestServer(t)
+	srv.AddMember(cc.OrganizationMember{Name: "Alice", Email: "alice@example.com", Roles: []cc.MemberRole{cc.MemberRoleOwner}})
+
+	result := runCLI(t, srv, "members", "invite", "--email", "bob@example.com", "--roles", "developer,analyst")
+	assert.Equal(t, cliResult{Stdout: "Invitation sent to bob@example.com\n"}, result)
+
+	result = runCLI(t, srv, "members", "update", "--email", "bob@example.com", "--roles", "admin")
+	assert.Equal(t, cliResult{Stdout: "Member updated successfully\n"}, result)
+
+	result = runCLI(t, srv, "members", "get")
+	assert.Equal(t, 0, result.ExitCode)
+	assert.Empty(t, result.Stderr)
+	assertGolden(t, "members_get.json", result.Stdout)
+
+	result = runCLI(t, srv, "members", "delete", "--email", "bob@example.com")
+	assert.Equal(t, cliResult{Stdout: "Member deleted successfully\n"}, result)
+	assert.Equal(t, []cc.OrganizationMember{{Name: "Alice", Email: "alice@example.com", Roles: []cc.MemberRole{cc.MemberRoleOwner}}}, srv.Members())
+}
+
+func Test_Members_rejected(t *testing.T) {
+	srv := newTestServer(t)
+	srv.AddMember(cc.OrganizationMember{Email: "alice@example.com", Roles: []cc.MemberRole{cc.MemberRoleOwner}})
+
+	result := runCLI(t, srv, "members", "invite", "--email", "bob@example.com", "--roles", "superuser")
+	assert.Equal(t, cliResult{Stderr: "Error: Unknown member role: superuser\n", ExitCode: 1}, result)
+	assert.Empty(t, srv.RequestsTo("POST /members/{email}"))
+
+	result = runCLI(t, srv, "members", "invite", "--email", "alice@example.com", "--roles", "developer")
+	assert.Equal(t, cliResult{Stderr: "Error: HTTP Error trying to inviteMember: 409\n", ExitCode: 1}, result)
+
+	result = runCLI(t, srv, "members", "update", "--email", "bob@example.com", "--roles", "admin")
+	assert.Equal(t, cliResult{Stderr: "Error: HTTP Error trying to updateMemberRoles: 404\n", ExitCode: 1}, result)
+
+	result = runCLI(t, srv, "members", "delete", "--email", "bob@example.com")
+	assert.Equal(t, cliResult{Stderr: "Error: HTTP Error trying to deleteMember: 404\n", ExitCode: 1}, result)
+
+	result = runCLI(t, srv, "members", "update", "--email", "alice@example.com")
+	assert.Equal(t, 1, result.ExitCode)
+	assert.Contains(t, result.Stderr, `required flag(s) "roles" not set`)
+	assert.Len(t, srv.Members(), 1)
+}
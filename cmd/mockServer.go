@@ -0,0 +1,107 @@
+/*
+Copyright © 2021
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/camunda-community-hub/camunda-cloud-go-client/pkg/cc/cctest"
+	"github.com/spf13/cobra"
+)
+
+var mockServerExample = `
+
+  # Serve a local stand-in of Camunda Cloud with the clusters of fixtures.yaml
+  cc-ctl mock-server --port 8080 --seed fixtures.yaml
+
+  # Then point cc-ctl at it with a context of the config file
+  cc-ctl clusters get --all --context local`
+
+// mockServerFlags are the flags of the mock-server command.
+type mockServerFlags struct {
+	port         int
+	seed         string
+	clientId     string
+	clientSecret string
+}
+
+func CreateMockServerCmd(cli *CLI) *cobra.Command {
+	flags := &mockServerFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "mock-server",
+		Short: "Serve a local stand-in of the Camunda Cloud APIs",
+		Long: `Serves the management and OAuth APIs of Camunda Cloud from memory over plain HTTP,
+for demos and local development without access to cloud.camunda.io. The initial
+channels, generations, plans, regions, clusters and members are read from a seed file. For example:` + mockServerExample,
+		Annotations: map[string]string{skipLoginAnnotation: "true"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			srv, err := flags.server()
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintln(cli.Out, "Serving Camunda Cloud on "+srv.URL)
+			fmt.Fprintln(cli.Out, "Target it with this context in the config file:")
+			fmt.Fprintln(cli.Out, mockServerContext(srv))
+
+			return http.ListenAndServe(":"+strconv.Itoa(flags.port), srv)
+		},
+	}
+
+	cmd.Flags().IntVarP(&flags.port, "port", "p", 8080, "Port to listen on")
+	cmd.Flags().StringVarP(&flags.seed, "seed", "s", "", "YAML file with the initial state of the server")
+	cmd.Flags().StringVar(&flags.clientId, "client-id", "", "Only accept this client id, any credentials are accepted by default")
+	cmd.Flags().StringVar(&flags.clientSecret, "client-secret", "", "Only accept this client secret")
+
+	return cmd
+}
+
+// server builds the server described by the flags, without starting it.
+func (flags *mockServerFlags) server() (*cctest.Server, error) {
+	srv := cctest.New()
+	srv.URL = "http://localhost:" + strconv.Itoa(flags.port)
+	srv.ClientID = flags.clientId
+	srv.ClientSecret = flags.clientSecret
+
+	if flags.seed != "" {
+		seed, err := cctest.LoadSeed(flags.seed)
+		if err != nil {
+			return nil, err
+		}
+		if err := srv.ApplySeed(seed); err != nil {
+			return nil, err
+		}
+	}
+	return srv, nil
+}
+
+// mockServerContext returns a config file snippet pointing cc-ctl at the server.
+func mockServerContext(srv *cctest.Server) string {
+	id, secret := srv.ClientID, srv.ClientSecret
+	if id == "" {
+		id, secret = "demo", "demo"
+	}
+	return fmt.Sprintf(`current-context: local
+contexts:
+  local:
+    client-id: %s
+    client-secret: %s
+    api-url: %s
+    login-url: %s`, id, secret, srv.URL, srv.URL)
+}
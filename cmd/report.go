@@ -0,0 +1,209 @@
+/*
+Copyright © 2021
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	cc "github.com/camunda-community-hub/camunda-cloud-go-client/pkg/cc/client"
+	"github.com/spf13/cobra"
+)
+
+var inventoryExample = `
+
+  # Count the clusters per plan and region
+  cc-ctl report inventory --group-by plan,region
+
+  # Export the clusters per channel and generation to a spreadsheet
+  cc-ctl report inventory --group-by channel,generation --format csv > inventory.csv
+
+  # Paste the inventory in a wiki page
+  cc-ctl report inventory --format markdown`
+
+// reportInventoryFlags are the flags of the report inventory command.
+type reportInventoryFlags struct {
+	groupBy []string
+	format  string
+	oldest  int
+}
+
+// reportTable is a section of a report, printed as a text table, CSV or Markdown.
+type reportTable struct {
+	title  string
+	header []string
+	rows   [][]string
+}
+
+func CreateReportCmd(cli *CLI) *cobra.Command {
+	reportCmd := &cobra.Command{
+		Use:   "report",
+		Short: "Report on the clusters of the organization",
+		Long:  "Used together with inventory, to count the clusters of the organization. For example:" + inventoryExample,
+	}
+
+	reportCmd.AddCommand(CreateReportInventoryCmd(cli))
+
+	return reportCmd
+}
+
+func CreateReportInventoryCmd(cli *CLI) *cobra.Command {
+	flags := &reportInventoryFlags{}
+
+	inventoryCmd := &cobra.Command{
+		Use:   "inventory",
+		Short: "Count the clusters per plan, region, channel or generation",
+		Long: `Counts the clusters and their Zeebe clients per group of the fields given with --group-by, with totals,
+the oldest clusters and the clusters without any Zeebe client. Internal Zeebe clients are not counted. The owners
+of a cluster are the users who created its Zeebe clients: the API does not tell who created a cluster.
+The csv format only holds the groups and the total, the json format holds every cluster. For example:` + inventoryExample,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if flags.format != "table" && flags.format != "csv" && flags.format != "markdown" && flags.format != "json" {
+				return fmt.Errorf("--format should be table, csv, markdown or json: %s", flags.format)
+			}
+			groupBy, err := cc.ParseInventoryFields(flags.groupBy)
+			if err != nil {
+				return err
+			}
+			if len(groupBy) == 0 {
+				return fmt.Errorf("--group-by should name at least one of %v", cc.InventoryFields)
+			}
+
+			report, err := cli.Client.Inventory(cmd.Context(), cc.ListOptions{}, cc.InventoryOptions{GroupBy: groupBy, Oldest: flags.oldest})
+			if err != nil {
+				return err
+			}
+
+			if flags.format == "json" {
+				return showJSON(cli.Out, report)
+			}
+			tables := inventoryTables(report)
+			if flags.format == "csv" {
+				return writeCSV(cli.Out, tables[0])
+			}
+			for i, table := range tables {
+				if i > 0 {
+					fmt.Fprintln(cli.Out)
+				}
+				if flags.format == "markdown" {
+					writeMarkdown(cli.Out, table)
+				} else if err := writeTable(cli.Out, table); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+
+	inventoryCmd.Flags().StringSliceVar(&flags.groupBy, "group-by", []string{"plan"}, "Fields to group the clusters by: plan, region, channel or generation")
+	inventoryCmd.Flags().StringVar(&flags.format, "format", "table", "Output format: table, csv, markdown or json")
+	inventoryCmd.Flags().IntVar(&flags.oldest, "oldest", 5, "Number of oldest clusters to show")
+
+	return inventoryCmd
+}
+
+// inventoryTables returns the groups with the total, the oldest clusters and the clusters without Zeebe clients.
+func inventoryTables(report cc.InventoryReport) []reportTable {
+	groupNames := []string{}
+	for _, field := range report.GroupBy {
+		groupNames = append(groupNames, string(field))
+	}
+	groups := reportTable{
+		title:  "Clusters per " + strings.Join(groupNames, " and "),
+		header: []string{},
+		rows:   [][]string{},
+	}
+	for _, field := range report.GroupBy {
+		groups.header = append(groups.header, strings.ToUpper(string(field[:1]))+string(field[1:]))
+	}
+	groups.header = append(groups.header, "Clusters", "Zeebe clients", "Without Zeebe clients", "Oldest", "Owners")
+	groupRow := func(values []string, group cc.InventoryGroup) []string {
+		return append(values, strconv.Itoa(group.Clusters), strconv.Itoa(group.ZeebeClients),
+			strconv.Itoa(group.WithoutZeebeClients), group.Oldest, strings.Join(group.Owners, ", "))
+	}
+	for _, group := range report.Groups {
+		groups.rows = append(groups.rows, groupRow(append([]string{}, group.Values...), group))
+	}
+	total := make([]string, len(report.GroupBy))
+	total[0] = "Total"
+	groups.rows = append(groups.rows, groupRow(total, report.Total))
+
+	oldest := reportTable{
+		title:  "Oldest clusters",
+		header: []string{"Name", "Plan", "Region", "Channel", "Generation", "Created", "Zeebe clients", "Owners"},
+		rows:   [][]string{},
+	}
+	for _, cluster := range report.Oldest {
+		oldest.rows = append(oldest.rows, []string{cluster.Name, cluster.Plan, cluster.Region, cluster.Channel, cluster.Generation,
+			cluster.Created.UTC().Format(time.RFC3339), strconv.Itoa(cluster.ZeebeClients), strings.Join(cluster.Owners, ", ")})
+	}
+
+	withoutClients := reportTable{
+		title:  "Clusters without Zeebe clients",
+		header: []string{"Name", "Plan", "Region", "Channel", "Generation", "Created"},
+		rows:   [][]string{},
+	}
+	for _, cluster := range report.WithoutZeebeClients {
+		withoutClients.rows = append(withoutClients.rows, []string{cluster.Name, cluster.Plan, cluster.Region, cluster.Channel,
+			cluster.Generation, cluster.Created.UTC().Format(time.RFC3339)})
+	}
+
+	return []reportTable{groups, oldest, withoutClients}
+}
+
+func writeTable(out io.Writer, table reportTable) error {
+	fmt.Fprintln(out, table.title+":")
+	w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, strings.ToUpper(strings.Join(table.header, "\t")))
+	for _, row := range table.rows {
+		cells := []string{}
+		for _, cell := range row {
+			cells = append(cells, valueOrDash(cell))
+		}
+		fmt.Fprintln(w, strings.Join(cells, "\t"))
+	}
+	return w.Flush()
+}
+
+func writeMarkdown(out io.Writer, table reportTable) {
+	fmt.Fprintf(out, "## %s\n\n", table.title)
+	fmt.Fprintf(out, "| %s |\n", strings.Join(table.header, " | "))
+	fmt.Fprintf(out, "|%s\n", strings.Repeat(" --- |", len(table.header)))
+	for _, row := range table.rows {
+		cells := []string{}
+		for _, cell := range row {
+			cells = append(cells, strings.ReplaceAll(valueOrDash(cell), "|", `\|`))
+		}
+		fmt.Fprintf(out, "| %s |\n", strings.Join(cells, " | "))
+	}
+}
+
+func writeCSV(out io.Writer, table reportTable) error {
+	w := csv.NewWriter(out)
+	if err := w.Write(table.header); err != nil {
+		return err
+	}
+	if err := w.WriteAll(table.rows); err != nil {
+		return err
+	}
+	return w.Error()
+}
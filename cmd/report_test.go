@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/camunda-community-hub/camunda-cloud-go-client/pkg/cc/cctest"
+	cc "github.com/camunda-community-hub/camunda-cloud-go-client/pkg/cc/client"
+	"github.com/stretchr/testify/assert"
+)
+
+// addInventory adds two production clusters in Europe with Zeebe clients and a development one in the US without.
+func addInventory(srv *cctest.Server) {
+	params := srv.Params()
+	europe := cc.K8sContext{UUID: params.Regions[0].Id, Name: params.Regions[0].Name}
+	us := cc.K8sContext{UUID: params.Regions[1].Id, Name: params.Regions[1].Name}
+	stable := params.Channels[0]
+
+	orders := srv.AddCluster(cc.Cluster{Name: "orders", ClusterPlantType: params.ClusterPlanTypes[1], K8sContext: europe,
+		Channel: stable, Generation: stable.DefaultGeneration, Created: testNow().Add(-72 * time.Hour)})
+	payments := srv.AddCluster(cc.Cluster{Name: "payments", ClusterPlantType: params.ClusterPlanTypes[1], K8sContext: europe,
+		Channel: stable, Generation: stable.AllowedGeneration[0], Created: testNow().Add(-240 * time.Hour)})
+	srv.AddCluster(cc.Cluster{Name: "sandbox", ClusterPlantType: params.ClusterPlanTypes[0], K8sContext: us,
+		Channel: stable, Generation: stable.DefaultGeneration, Created: testNow().Add(-24 * time.Hour)})
+	srv.CreatedBy = "alice@example.com"
+	srv.AddZeebeClient(orders.ID, "worker")
+	srv.AddZeebeClient(payments.ID, "worker")
+	srv.CreatedBy = "bob@example.com"
+	srv.AddZeebeClient(orders.ID, "starter")
+}
+
+func Test_ReportInventory(t *testing.T) {
+	srv := newTestServer(t)
+	addInventory(srv)
+
+	for _, format := range []string{"table", "markdown", "csv"} {
+		result := runCLI(t, srv, "report", "inventory", "--group-by", "plan,region", "--format", format)
+
+		assert.Equal(t, 0, result.ExitCode, result.Stderr)
+		assertGolden(t, "report_inventory_"+format, result.Stdout)
+	}
+}
+
+func Test_ReportInventory_invalidFlags(t *testing.T) {
+	srv := newTestServer(t)
+
+	result := runCLI(t, srv, "report", "inventory", "--format", "xlsx")
+	assert.Equal(t, cliResult{Stderr: "Error: --format should be table, csv, markdown or json: xlsx\n", ExitCode: 1}, result)
+
+	result = runCLI(t, srv, "report", "inventory", "--group-by", "owner")
+	assert.Equal(t, cliResult{Stderr: "Error: Unknown inventory field: owner\n", ExitCode: 1}, result)
+}
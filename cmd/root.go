@@ -16,33 +16,21 @@ limitations under the License.
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
 	"strconv"
 
 	cc "github.com/camunda-community-hub/camunda-cloud-go-client/pkg/cc/client"
-
 	"github.com/spf13/cobra"
-
-	homedir "github.com/mitchellh/go-homedir"
 	"github.com/spf13/viper"
 )
 
-var cfgFile string
-var client cc.CCClient
-
-var ClientId = os.Getenv("CC_CLIENT_ID")
-var ClientSecret = os.Getenv("CC_CLIENT_SECRET")
 var TracerURL = os.Getenv("CC_TRACER_URL")
 var TracingEnabled, _ = strconv.ParseBool(os.Getenv("CC_TRACING_ENABLED"))
-var CCApiURL=os.Getenv("CC_API_URL")
-
-// rootCmd represents the base command when called without any subcommands
-var rootCmd = &cobra.Command{
-	Use:                   "cc-ctl",
-	DisableFlagsInUseLine: true,
-	Short:                 "Camunda Cloud CLI to manage Camunda Cloud Resources",
-	Long: `Camunda Cloud CLI to interact with Camunda Cloud Resources.
+
+const rootLong = `Camunda Cloud CLI to interact with Camunda Cloud Resources.
   You can create a Camunda Cloud Account here: https://accounts.cloud.camunda.io/signup
   Then you need to go to <YOUR USER>(Top right corner) -> Organization Settings -> Cloud Management API 
   and Create a new client. You need to copy and save the Client Id and the Secret Id from that client. 
@@ -67,7 +55,98 @@ var rootCmd = &cobra.Command{
   cc-ctl clusters delete --name <cluster_name>
 
   # Create cluster from default configuration
-  cc-ctl clusters create --default --name <cluster_name>`,
+  cc-ctl clusters create --default --name <cluster_name>
+
+  Instead of the environment variables, credentials and endpoints can be read
+  from a context of the config file, selected with --context or current-context.`
+
+// skipLoginAnnotation marks commands that do not talk to Camunda Cloud.
+const skipLoginAnnotation = "cc-ctl/skip-login"
+
+// NewRootCmd builds the cc-ctl command tree around the streams, client and config of the CLI.
+func NewRootCmd(cli *CLI) *cobra.Command {
+	cli.setDefaults()
+
+	var cfgFile string
+	readConfig := cli.Config == nil
+	if readConfig {
+		cli.Config = viper.New()
+	}
+
+	// rootCmd represents the base command when called without any subcommands
+	rootCmd := &cobra.Command{
+		Use:                   "cc-ctl",
+		DisableFlagsInUseLine: true,
+		Short:                 "Camunda Cloud CLI to manage Camunda Cloud Resources",
+		Long:                  rootLong,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			// Flags are valid by now, errors of the command itself do not need the usage.
+			cmd.SilenceUsage = true
+
+			if readConfig || cfgFile != "" {
+				if err := readConfigFile(cli.Config, cfgFile, cli.ErrOut); err != nil {
+					return err
+				}
+			}
+
+			if cmd.Name() == "help" || cmd.Annotations[skipLoginAnnotation] == "true" {
+				return nil
+			}
+			return login(cmd.Context(), cli)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+	rootCmd.SetIn(cli.In)
+	rootCmd.SetOut(cli.Out)
+	rootCmd.SetErr(cli.ErrOut)
+
+	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.camunda-cloud-go-client.yaml)")
+	rootCmd.PersistentFlags().StringVar(&cli.contextName, "context", "", "context of the config file to use instead of current-context and the CC_* environment variables")
+	rootCmd.PersistentFlags().BoolVar(&cli.rollbackOnCancel, "rollback-on-cancel", false, "undo what a command did when it is interrupted, for example delete the cluster it was waiting for")
+
+	rootCmd.AddCommand(CreateClustersCmd(cli))
+	rootCmd.AddCommand(CreateZbClientCmd(cli))
+	rootCmd.AddCommand(CreateMembersCmd(cli))
+	rootCmd.AddCommand(CreateMockServerCmd(cli))
+	rootCmd.AddCommand(CreateDoctorCmd(cli))
+	rootCmd.AddCommand(CreateEphemeralCmd(cli))
+	rootCmd.AddCommand(CreateTemplatesCmd(cli))
+	rootCmd.AddCommand(CreateFleetCmd(cli))
+	rootCmd.AddCommand(CreateReportCmd(cli))
+	rootCmd.AddCommand(CreateCompareCmd(cli))
+
+	return rootCmd
+}
+
+// login resolves the context and logs the client in.
+func login(ctx context.Context, cli *CLI) error {
+	context, err := resolveContext(cli.Config, cli.Getenv, cli.contextName)
+	if err != nil {
+		return err
+	}
+	if envVarsExist := checkEnvVars(context.ClientID, context.ClientSecret); !envVarsExist {
+		fmt.Fprintln(cli.Out, rootLong)
+		return fmt.Errorf("CC_CLIENT_ID and CC_CLIENT_SECRET are not set")
+	}
+	configureClient(cli.Client, context)
+	cli.context = context
+
+	login, err := cli.Client.LoginWithContext(ctx, context.ClientID, context.ClientSecret)
+	if err != nil || !login {
+		return fmt.Errorf("Error trying to Login to Camunda Cloud, "+
+			"please check your CC_CLIENT_ID and CC_CLIENT_SECRET! \n %s", err)
+	}
+	// The cluster params give the defaults of cluster creation. Tokens without the Cluster scope cannot load them,
+	// and do not need them.
+	if cli.Client.RequireScopes(cc.ScopeCluster) != nil {
+		return nil
+	}
+	if _, err := cli.Client.GetClusterParamsWithContext(ctx); err != nil {
+		return fmt.Errorf("cannot load the cluster params: %v", err)
+	}
+	return nil
 }
 
 // checkEnvVars makes sure that ClientId and ClientSecret are not null
@@ -80,69 +159,62 @@ func checkEnvVars(id string, secret string) bool {
 	return envVarsExist
 }
 
-// Execute adds all child commands to the root command and sets flags appropriately.
-// This is called by main.main(). It only needs to happen once to the rootCmd.
-func Execute() {
-	if envVarsExist := checkEnvVars(ClientId, ClientSecret); !envVarsExist {
-		fmt.Println(rootCmd.Long)
-		os.Exit(1)
-	}
+// exitError makes cc-ctl exit with the code, for example the one of a command it ran.
+type exitError struct {
+	code int
+}
 
-	if TracingEnabled != true {
-		TracingEnabled = false
-	}
-	client.TracingEnabled(TracingEnabled)
+func (e *exitError) Error() string {
+	return fmt.Sprintf("exit status %d", e.code)
+}
 
-	if CCApiURL == "" {
-		CCApiURL = "cloud.camunda.io"
-	}
-	client.SetCCApiURL(CCApiURL)
+// Run executes cc-ctl with the arguments and returns its exit code.
+func Run(cli *CLI, args []string) int {
+	rootCmd := NewRootCmd(cli)
+	rootCmd.SetArgs(args)
 
-	if TracerURL == ""{
-		TracerURL = "localhost:14268"
+	ctx, stop := cli.notifyContext(cli.BaseContext)
+	defer stop()
+	err := rootCmd.ExecuteContext(ctx)
+	if err != nil && ctx.Err() != nil {
+		cli.compensate()
 	}
-	client.SetTracerURL(TracerURL)
-
-	if TracingEnabled {
-		flush := client.InitTracer()
-		defer flush()
+	if sig := cli.interruptSignal(); sig != nil {
+		return signalExitCode(sig)
 	}
 
-	login, err := client.Login(ClientId, ClientSecret)
-	client.GetClusterParams()
-	if err != nil || !login {
-
-		fmt.Errorf("Error trying to Login to Camunda Cloud, "+
-			"please check your CC_CLIENT_ID and CC_CLIENT_SECRET! \n %s", err)
-		os.Exit(1)
-
+	if err != nil {
+		exit := &exitError{}
+		if errors.As(err, &exit) {
+			return exit.code
+		}
+		return 1
 	}
-	cobra.CheckErr(rootCmd.Execute())
+	return 0
 }
 
-func init() {
-	cobra.OnInitialize(initConfig)
-}
+// Execute runs cc-ctl with the arguments and streams of the process, and exits with its exit code.
+// This is called by main.main().
+func Execute() {
+	client := &cc.CCClient{}
+	client.Use(cc.UserAgent("cc-ctl"), cc.RequestID())
 
-// initConfig reads in config file and ENV variables if set.
-func initConfig() {
-	if cfgFile != "" {
-		// Use config file from the flag.
-		viper.SetConfigFile(cfgFile)
-	} else {
-		// Find home directory.
-		home, err := homedir.Dir()
-		cobra.CheckErr(err)
-
-		// Search config in home directory with name ".camunda-cloud-go-client" (without extension).
-		viper.AddConfigPath(home)
-		viper.SetConfigName(".camunda-cloud-go-client")
+	if TracingEnabled != true {
+		TracingEnabled = false
 	}
+	client.TracingEnabled(TracingEnabled)
 
-	viper.AutomaticEnv() // read in environment variables that match
+	if TracerURL == "" {
+		TracerURL = "localhost:14268"
+	}
+	client.SetTracerURL(TracerURL)
 
-	// If a config file is found, read it in.
-	if err := viper.ReadInConfig(); err == nil {
-		fmt.Fprintln(os.Stderr, "Using config file:", viper.ConfigFileUsed())
+	flush := func() {}
+	if TracingEnabled {
+		flush = client.InitTracer()
 	}
+
+	exitCode := Run(&CLI{IOStreams: SystemIOStreams(), Client: client}, os.Args[1:])
+	flush()
+	os.Exit(exitCode)
 }
@@ -1,9 +1,16 @@
 package cmd
 
 import (
-	"os"
-	"os/exec"
+	"context"
+	"strings"
 	"testing"
+
+	"github.com/camunda-community-hub/camunda-cloud-go-client/pkg/cc/cctest"
+	cc "github.com/camunda-community-hub/camunda-cloud-go-client/pkg/cc/client"
+	"github.com/camunda-community-hub/camunda-cloud-go-client/pkg/cc/client/mocks"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func Test_checkEnvVars(t *testing.T) {
@@ -12,18 +19,75 @@ func Test_checkEnvVars(t *testing.T) {
 	}
 }
 
-func Test_Execute_envVarsNotPresent(t *testing.T) {
-	if os.Getenv("BE_EXECUTE") == "1" {
-		ClientId = ""
-		ClientSecret = ""
-		Execute()
-		return
-	}
-	cmd := exec.Command(os.Args[0], "-test.run=Test_Execute_envVarsNotPresent")
-	cmd.Env = append(os.Environ(), "BE_EXECUTE=1")
-	err := cmd.Run()
-	if e, ok := err.(*exec.ExitError); ok && !e.Success() {
-		return
+func Test_Run_envVarsNotPresent(t *testing.T) {
+	stdout, stderr := &strings.Builder{}, &strings.Builder{}
+	cli := &CLI{
+		IOStreams: IOStreams{Out: stdout, ErrOut: stderr},
+		Config:    viper.New(),
+		Getenv:    func(string) string { return "" },
 	}
-	t.Fatalf("process ran with err %v, want exit status 1", err)
+
+	exitCode := Run(cli, []string{"clusters", "get", "--all"})
+
+	assert.Equal(t, 1, exitCode)
+	assert.Contains(t, stdout.String(), "export CC_CLIENT_ID=<YOUR CLIENT ID>")
+	assert.Equal(t, "Error: CC_CLIENT_ID and CC_CLIENT_SECRET are not set\n", stderr.String())
+}
+
+func Test_Run_unknownContext(t *testing.T) {
+	srv := newTestServer(t)
+
+	result := runCLI(t, srv, "clusters", "get", "--all", "--context", "missing")
+
+	assert.Equal(t, 1, result.ExitCode)
+	assert.Equal(t, "Error: context \"missing\" not found in the config file\n", result.Stderr)
+}
+
+func Test_Run_loginFailure(t *testing.T) {
+	srv := newTestServer(t)
+	srv.ClientID, srv.ClientSecret = "id", "secret"
+
+	result := runCLI(t, srv, "clusters", "get", "--all")
+
+	assert.Equal(t, 1, result.ExitCode)
+	assert.Contains(t, result.Stderr, "Error trying to Login to Camunda Cloud")
+	assert.Empty(t, result.Stdout)
+}
+
+func Test_Run_clusterParamsFailure(t *testing.T) {
+	srv := newTestServer(t)
+	srv.InjectFault("GET /clusters/parameters", cctest.Fail(403, 1))
+
+	result := runCLI(t, srv, "clusters", "create", "--name", "orders")
+
+	assert.Equal(t, cliResult{Stderr: "Error: cannot load the cluster params: HTTP Error trying to getClusterParams: 403\n", ExitCode: 1}, result)
+	assert.Empty(t, srv.RequestsTo("POST /clusters"))
+}
+
+func Test_Run_withoutClusterScope(t *testing.T) {
+	srv := newTestServer(t)
+	srv.Scope = "Members"
+
+	result := runCLI(t, srv, "members", "get")
+
+	assert.Equal(t, 0, result.ExitCode, result.Stderr)
+	assert.Empty(t, srv.RequestsTo("GET /clusters/parameters"))
+}
+
+func Test_Run_mockClient(t *testing.T) {
+	srv := newTestServer(t)
+	client := mocks.NewCCAPI(t)
+	client.On("LoginWithContext", mock.Anything, "cctest", "cctest").Return(true, nil)
+	client.On("RequireScopes", cc.ScopeCluster).Return(nil)
+	client.On("GetClusterParamsWithContext", mock.Anything).Return(&cc.ClusterParams{}, nil)
+	client.On("GetMembersWithContext", mock.Anything).Return([]cc.OrganizationMember{{Name: "Alice", Email: "alice@example.com"}}, nil)
+
+	result := runCLIClient(t, srv, context.Background(), client, "members", "get")
+
+	assert.Equal(t, 0, result.ExitCode, result.Stderr)
+	assert.Contains(t, result.Stdout, "alice@example.com")
+	assert.Empty(t, srv.Requests(), "the mock answers instead of the server")
+
+	result = runCLIClient(t, srv, context.Background(), client, "doctor")
+	assert.Equal(t, cliResult{Stderr: "Error: doctor checks the endpoints and token of the Camunda Cloud client, not of a *mocks.CCAPI\n", ExitCode: 1}, result)
 }
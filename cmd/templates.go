@@ -0,0 +1,96 @@
+/*
+Copyright © 2021
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+var templatesExample = `
+
+  # Templates are stored in the config file
+  templates:
+    staging:
+      plan: Production - S
+      channel: Stable
+      generation: default
+      region: Europe West
+
+  # List the templates
+  cc-ctl templates list
+
+  # Show a template
+  cc-ctl templates show staging
+
+  # Create a cluster from a template, overriding its region
+  cc-ctl clusters create --template staging --name orders --region 'US East'`
+
+func CreateTemplatesCmd(cli *CLI) *cobra.Command {
+	templatesCmd := &cobra.Command{
+		Use:   "templates",
+		Short: "List the cluster templates of the config file",
+		Long: `Used together with list or show, to look at the cluster templates of the config file. A template gives
+the plan, channel, generation and region of a cluster by name. The generation is pinned, or default to follow
+the default generation of the channel. For example:` + templatesExample,
+	}
+
+	templatesCmd.AddCommand(CreateTemplatesListCmd(cli))
+	templatesCmd.AddCommand(CreateTemplatesShowCmd(cli))
+
+	return templatesCmd
+}
+
+func CreateTemplatesListCmd(cli *CLI) *cobra.Command {
+	return &cobra.Command{
+		Use:         "list",
+		Short:       "List the cluster templates",
+		Args:        cobra.NoArgs,
+		Annotations: map[string]string{skipLoginAnnotation: "true"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			templates, err := loadTemplates(cli.Config)
+			if err != nil {
+				return err
+			}
+
+			w := tabwriter.NewWriter(cli.Out, 0, 0, 3, ' ', 0)
+			fmt.Fprintln(w, "NAME\tPLAN\tCHANNEL\tGENERATION\tREGION")
+			for _, t := range templates {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", t.Name,
+					valueOrDash(t.Plan), valueOrDash(t.Channel), valueOrDash(t.Generation), valueOrDash(t.Region))
+			}
+			return w.Flush()
+		},
+	}
+}
+
+func CreateTemplatesShowCmd(cli *CLI) *cobra.Command {
+	return &cobra.Command{
+		Use:         "show <name>",
+		Short:       "Show a cluster template",
+		Args:        cobra.ExactArgs(1),
+		Annotations: map[string]string{skipLoginAnnotation: "true"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			template, err := loadTemplate(cli.Config, args[0])
+			if err != nil {
+				return err
+			}
+			return showJSON(cli.Out, template)
+		},
+	}
+}
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const templatesConfig = `
+templates:
+  staging:
+    plan: Production - S
+    channel: Stable
+    generation: default
+    region: US East
+  ci:
+    generation: Zeebe 0.26.1
+`
+
+// writeConfig writes a config file for --config and returns its path.
+func writeConfig(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func Test_TemplatesListAndShow(t *testing.T) {
+	srv := newTestServer(t)
+	config := writeConfig(t, templatesConfig)
+
+	result := runCLI(t, srv, "templates", "list", "--config", config)
+	assert.Equal(t, 0, result.ExitCode)
+	assertGolden(t, "templates_list", result.Stdout)
+
+	result = runCLI(t, srv, "templates", "show", "staging", "--config", config)
+	assert.Equal(t, 0, result.ExitCode)
+	assertGolden(t, "templates_show.json", result.Stdout)
+
+	result = runCLI(t, srv, "templates", "show", "perf", "--config", config)
+	assert.Equal(t, 1, result.ExitCode)
+	assert.Contains(t, result.Stderr, "Error: template \"perf\" not found in the config file\n")
+	assert.Empty(t, srv.RequestsTo("POST /oauth/token"))
+}
+
+func Test_ClustersCreate_template(t *testing.T) {
+	srv := newTestServer(t)
+	config := writeConfig(t, templatesConfig)
+
+	result := runCLI(t, srv, "clusters", "create", "--template", "staging", "--name", "orders", "--config", config)
+	assert.Equal(t, 0, result.ExitCode)
+	result = runCLI(t, srv, "clusters", "create", "--template", "staging", "--name", "payments", "--region", "Europe West", "--config", config)
+	assert.Equal(t, 0, result.ExitCode)
+	result = runCLI(t, srv, "clusters", "create", "--template", "ci", "--name", "ci-1", "--config", config)
+	assert.Equal(t, 0, result.ExitCode)
+
+	clusters := srv.Clusters()
+	assert.Len(t, clusters, 3)
+	assert.Equal(t, "plan-production-s", clusters[0].ClusterPlantType.Id)
+	assert.Equal(t, "generation-stable-2", clusters[0].Generation.Id)
+	assert.Equal(t, "region-us-east1", clusters[0].K8sContext.UUID)
+	assert.Equal(t, "region-europe-west1", clusters[1].K8sContext.UUID)
+	assert.Equal(t, "plan-development", clusters[2].ClusterPlantType.Id)
+	assert.Equal(t, "generation-stable-1", clusters[2].Generation.Id)
+
+	result = runCLI(t, srv, "clusters", "create", "--template", "staging", "--name", "perf", "--region", "Mars", "--config", config)
+	assert.Equal(t, 1, result.ExitCode)
+	assert.Contains(t, result.Stderr, "Error: No region found with name or id: Mars\n")
+}
@@ -5,7 +5,7 @@ Licensed under the Apache License, Version 2.0 (the "License");
 you may not use this file except in compliance with the License.
 You may obtain a copy of the License at
 
-    http://www.apache.org/licenses/LICENSE-2.0
+	http://www.apache.org/licenses/LICENSE-2.0
 
 Unless required by applicable law or agreed to in writing, software
 distributed under the License is distributed on an "AS IS" BASIS,
@@ -16,13 +16,10 @@ limitations under the License.
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
-	"github.com/spf13/cobra"
-)
 
-var (
-	cluster string
+	cc "github.com/camunda-community-hub/camunda-cloud-go-client/pkg/cc/client"
+	"github.com/spf13/cobra"
 )
 
 var (
@@ -30,61 +27,152 @@ var (
 	zeebeClientGetExample    = `
   # List all Zeebe clients
   cc-ctl zb-client get --cluster=<cluster_id> --all`
-	zeebeClientCreateExample = ""
+	zeebeClientCreateExample = `
+
+  # Create a Zeebe client with the default scopes
+  cc-ctl zb-client create --cluster=<cluster_id> --name=<client_name>
+
+  # Create a Zeebe client that can only access Zeebe
+  cc-ctl zb-client create --cluster=<cluster_id> --name=<client_name> --scopes=zeebe
+
+  # Create it unless it already exists, for pipelines run more than once
+  cc-ctl zb-client create --cluster=<cluster_id> --name=<client_name> --scopes=zeebe --if-not-exists`
+	zeebeClientUpdateExample = `
+
+  # Change the scopes of an existing Zeebe client
+  cc-ctl zb-client update --cluster=<cluster_id> --client=<client_id> --scopes=zeebe,operate`
 )
 
 // zbClientCmd represents the zb-client command
-func CreateZbClientCmd() *cobra.Command {
+func CreateZbClientCmd(cli *CLI) *cobra.Command {
 
 	zbClientCmd := &cobra.Command{
 		Use:   "zb-client [options]",
 		Short: "Manage your zeebe clients resources on Camunda Cloud",
 		Long: `Used together [OPTIONS] like get, create, delete for manage your zeebe clients resources on Camunda Cloud. For example:` +
-			zeebeClientGetExample + zeebeClientCreateExample + zeebeClientDeleteExample,
+			zeebeClientGetExample + zeebeClientCreateExample + zeebeClientUpdateExample + zeebeClientDeleteExample,
 	}
 
+	zbClientCmd.AddCommand(CreateZbClientGetCmd(cli))
+	zbClientCmd.AddCommand(CreateZbClientCreateCmd(cli))
+	zbClientCmd.AddCommand(CreateZbClientUpdateCmd(cli))
+
 	return zbClientCmd
 }
 
-func init() {
-
-	zbClientCmd := CreateZbClientCmd()
-	zbClientGetCmd := CreateZbClientGetCmd()
+func CreateZbClientGetCmd(cli *CLI) *cobra.Command {
+	var cluster string
 
-	zbClientGetCmd.Flags().StringVarP(&cluster, "cluster", "n", "", "cc-ctl zb-client get --cluster=<cluster_id>")
-	zbClientCmd.MarkFlagRequired("cluster")
-
-	zbClientCmd.AddCommand(zbClientGetCmd)
-	rootCmd.AddCommand(zbClientCmd)
-}
-
-func CreateZbClientGetCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "get",
 		Short: "Get Zeebe clients",
 		Long:  "Used together with zb-client command, to get your zeebe clients on Camunda Cloud. For example:" + zeebeClientGetExample,
-		RunE:  ZbClientGetRunE,
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			clients, err := cli.Client.GetZeebeClientsWithContext(cmd.Context(), cluster)
+
+			if err != nil {
+				return err
+			}
+
+			return showJSON(cli.Out, clients)
+		},
 	}
 
+	cmd.Flags().StringVarP(&cluster, "cluster", "n", "", "cc-ctl zb-client get --cluster=<cluster_id>")
+	cmd.MarkFlagRequired("cluster")
+
 	return cmd
 }
 
-func ZbClientGetRunE(cmd *cobra.Command, args []string) error {
+func CreateZbClientCreateCmd(cli *CLI) *cobra.Command {
+	var cluster, clientName string
+	var scopeNames []string
+	var ifNotExists bool
+
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create a Zeebe client",
+		Long:  "Used together with zb-client command, to create a zeebe client on Camunda Cloud. For example:" + zeebeClientCreateExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
 
-	clients, err := client.GetZeebeClients(cluster)
+			scopes, err := cc.ParseZeebeClientScopes(scopeNames)
 
-	if err != nil {
-		fmt.Println("err 1")
-		return err
+			if err != nil {
+				return err
+			}
+
+			if ifNotExists {
+				ensured, _, err := cli.Client.EnsureZeebeClient(cmd.Context(), cluster, clientName, scopes...)
+				if err != nil {
+					return err
+				}
+				return showJSON(cli.Out, ensured)
+			}
+
+			created, err := cli.Client.CreateZeebeClientWithContext(cmd.Context(), cluster, clientName, scopes...)
+
+			if err != nil {
+				return err
+			}
+
+			return showJSON(cli.Out, created)
+		},
 	}
 
-	data, err2 := json.MarshalIndent(clients, "", "  ")
+	cmd.Flags().StringVarP(&cluster, "cluster", "c", "", "Cluster's id")
+	cmd.Flags().StringVarP(&clientName, "name", "n", "", "Zeebe client's name")
+	cmd.Flags().StringSliceVarP(&scopeNames, "scopes", "s", nil, scopesFlagUsage())
+	cmd.Flags().BoolVar(&ifNotExists, "if-not-exists", false, "Reuse the client with this name when it has the scopes, fail when it has others. The secret is only shown on creation")
+	cmd.MarkFlagRequired("cluster")
+	cmd.MarkFlagRequired("name")
+
+	return cmd
+}
+
+func CreateZbClientUpdateCmd(cli *CLI) *cobra.Command {
+	var cluster, zbClientId string
+	var scopeNames []string
+
+	cmd := &cobra.Command{
+		Use:   "update",
+		Short: "Update the scopes of a Zeebe client",
+		Long:  "Used together with zb-client command, to change the scopes of a zeebe client on Camunda Cloud. For example:" + zeebeClientUpdateExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			scopes, err := cc.ParseZeebeClientScopes(scopeNames)
+
+			if err != nil {
+				return err
+			}
+
+			if _, err := cli.Client.UpdateZeebeClientWithContext(cmd.Context(), cluster, zbClientId, scopes...); err != nil {
+				return err
+			}
+
+			fmt.Fprintln(cli.Out, "Zeebe client updated successfully")
 
-	if err2 != nil {
-		return err2
+			return nil
+		},
 	}
 
-	fmt.Println(string(data))
+	cmd.Flags().StringVarP(&cluster, "cluster", "c", "", "Cluster's id")
+	cmd.Flags().StringVarP(&zbClientId, "client", "i", "", "Zeebe client's id")
+	cmd.Flags().StringSliceVarP(&scopeNames, "scopes", "s", nil, scopesFlagUsage())
+	cmd.MarkFlagRequired("cluster")
+	cmd.MarkFlagRequired("client")
+	cmd.MarkFlagRequired("scopes")
 
-	return nil
+	return cmd
+}
+
+func scopesFlagUsage() string {
+	names := ""
+	for i, scope := range cc.ZeebeClientScopes {
+		if i > 0 {
+			names += ","
+		}
+		names += string(scope)
+	}
+	return "Comma separated Zeebe client scopes (" + names + ")"
 }
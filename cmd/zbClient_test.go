@@ -3,9 +3,70 @@ package cmd
 import (
 	"testing"
 
+	cc "github.com/camunda-community-hub/camunda-cloud-go-client/pkg/cc/client"
 	"github.com/stretchr/testify/assert"
 )
 
 func Test_Default(t *testing.T) {
 	assert.Equal(t, true, true)
 }
+
+func Test_ZbClientCreate(t *testing.T) {
+	srv := newTestServer(t)
+	cluster := srv.AddCluster(cc.Cluster{Name: "workers"})
+
+	result := runCLI(t, srv, "zb-client", "create", "--cluster", cluster.ID, "--name", "worker", "--scopes", "zeebe")
+	assert.Equal(t, 0, result.ExitCode)
+	assert.Empty(t, result.Stderr)
+	assertGolden(t, "zb_client_create.json", result.Stdout)
+
+	result = runCLI(t, srv, "zb-client", "create", "--cluster", cluster.ID, "--name", "worker", "--scopes", "console")
+	assert.Equal(t, cliResult{Stderr: "Error: Unknown zeebe client scope: console\n", ExitCode: 1}, result)
+}
+
+func Test_ZbClientCreate_ifNotExists(t *testing.T) {
+	srv := newTestServer(t)
+	cluster := srv.AddCluster(cc.Cluster{Name: "workers"})
+	created, _ := srv.AddZeebeClient(cluster.ID, "worker", cc.ZeebeClientScopeZeebe)
+
+	result := runCLI(t, srv, "zb-client", "create", "--cluster", cluster.ID, "--name", "worker", "--scopes", "zeebe", "--if-not-exists")
+	assert.Equal(t, 0, result.ExitCode)
+	assert.Contains(t, result.Stdout, `"clientId": "`+created.ClientID+`"`)
+	assert.Contains(t, result.Stdout, `"clientSecret": ""`)
+
+	result = runCLI(t, srv, "zb-client", "create", "--cluster", cluster.ID, "--name", "worker", "--scopes", "zeebe,operate", "--if-not-exists")
+	assert.Equal(t, cliResult{Stderr: "Error: Zeebe client worker (" + created.ClientID + ") already exists with other settings: " +
+		"scopes is Zeebe, want Operate,Zeebe\n", ExitCode: 1}, result)
+	assert.Len(t, srv.ZeebeClients(cluster.ID), 1)
+}
+
+func Test_ZbClientUpdate(t *testing.T) {
+	srv := newTestServer(t)
+	cluster := srv.AddCluster(cc.Cluster{Name: "workers"})
+	created, _ := srv.AddZeebeClient(cluster.ID, "worker", cc.ZeebeClientScopeZeebe)
+
+	result := runCLI(t, srv, "zb-client", "update", "--cluster", cluster.ID, "--client", created.ClientID, "--scopes", "zeebe,operate")
+	assert.Equal(t, cliResult{Stdout: "Zeebe client updated successfully\n"}, result)
+	clients := srv.ZeebeClients(cluster.ID)
+	if assert.Len(t, clients, 1) {
+		assert.ElementsMatch(t, []cc.ZeebeClientScope{cc.ZeebeClientScopeZeebe, cc.ZeebeClientScopeOperate}, clients[0].Permissions)
+	}
+
+	result = runCLI(t, srv, "zb-client", "update", "--cluster", cluster.ID, "--client", created.ClientID, "--scopes", "console")
+	assert.Equal(t, cliResult{Stderr: "Error: Unknown zeebe client scope: console\n", ExitCode: 1}, result)
+
+	result = runCLI(t, srv, "zb-client", "update", "--cluster", cluster.ID, "--client", "missing", "--scopes", "zeebe")
+	assert.Equal(t, 1, result.ExitCode)
+	assert.Equal(t, "Error: HTTP Error trying to updateZeebeClient: 404\n", result.Stderr)
+	assert.Len(t, srv.RequestsTo("PUT /clusters/{clusterId}/clients/{clientId}"), 2, "invalid scopes are rejected before any request")
+}
+
+func Test_ZbClientUpdate_requiredFlags(t *testing.T) {
+	srv := newTestServer(t)
+
+	result := runCLI(t, srv, "zb-client", "update", "--cluster", "cluster-1", "--client", "client-1")
+
+	assert.Equal(t, 1, result.ExitCode)
+	assert.Contains(t, result.Stderr, `required flag(s) "scopes" not set`)
+	assert.Empty(t, srv.RequestsTo("PUT /clusters/{clusterId}/clients/{clientId}"))
+}
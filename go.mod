@@ -11,4 +11,5 @@ require (
 	go.opentelemetry.io/otel/exporters/trace/jaeger v0.19.0
 	go.opentelemetry.io/otel/sdk v0.19.0
 	go.opentelemetry.io/otel/trace v0.19.0
+	gopkg.in/yaml.v2 v2.4.0
 )
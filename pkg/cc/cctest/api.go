@@ -0,0 +1,745 @@
+package cctest
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	cc "github.com/camunda-community-hub/camunda-cloud-go-client/pkg/cc/client"
+)
+
+type clusterState struct {
+	cluster cc.Cluster
+	clients []zeebeClientState
+	secrets map[string]string
+	polls   int
+	// upgradeStarting counts down the polls before an upgraded cluster reports Updating.
+	upgradeStarting int
+}
+
+type zeebeClientState struct {
+	client cc.ZeebeClientResponse
+	secret string
+}
+
+// DefaultParams returns the channels, generations, plans and regions the server starts with.
+func DefaultParams() cc.ClusterParams {
+	stableGeneration := cc.Generation{Id: "generation-stable-2", Name: "Zeebe 1.0.0"}
+	alphaGeneration := cc.Generation{Id: "generation-alpha-1", Name: "Zeebe 1.1.0-alpha1"}
+
+	return cc.ClusterParams{
+		Channels: []cc.Channel{
+			{
+				Id:                "channel-stable",
+				Name:              "Stable",
+				DefaultGeneration: stableGeneration,
+				AllowedGeneration: []cc.Generation{
+					{Id: "generation-stable-1", Name: "Zeebe 0.26.1"},
+					stableGeneration,
+				},
+			},
+			{
+				Id:                "channel-alpha",
+				Name:              "Alpha",
+				DefaultGeneration: alphaGeneration,
+				AllowedGeneration: []cc.Generation{alphaGeneration},
+			},
+		},
+		ClusterPlanTypes: []cc.ClusterPlantType{
+			{Id: "plan-development", Name: "Development"},
+			{Id: "plan-production-s", Name: "Production - S"},
+		},
+		Regions: []cc.Region{
+			{Id: "region-europe-west1", Name: "Europe West"},
+			{Id: "region-us-east1", Name: "US East"},
+		},
+	}
+}
+
+// SetParams replaces the channels, generations, plans and regions offered by the server.
+func (s *Server) SetParams(params cc.ClusterParams) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.params = params
+}
+
+// Params returns the channels, generations, plans and regions offered by the server.
+func (s *Server) Params() cc.ClusterParams {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.params
+}
+
+// AddCluster stores a cluster as if it had been created earlier. A missing id, creation
+// time or status is filled in, the cluster is reported Healthy unless a status is given.
+func (s *Server) AddCluster(cluster cc.Cluster) cc.Cluster {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if cluster.ID == "" {
+		cluster.ID = s.nextID()
+	}
+	if cluster.Created.IsZero() {
+		cluster.Created = s.Now().UTC()
+	}
+	if cluster.Status.Ready == "" {
+		cluster.Status = statusFor(cc.HealthHealthy)
+	}
+	fillLinks(&cluster)
+	s.clusters = append(s.clusters, &clusterState{cluster: cluster})
+	return cluster
+}
+
+// Clusters returns the clusters currently stored.
+func (s *Server) Clusters() []cc.Cluster {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	clusters := []cc.Cluster{}
+	for _, state := range s.clusters {
+		clusters = append(clusters, state.cluster)
+	}
+	return clusters
+}
+
+// ZeebeClients returns the Zeebe clients stored for a cluster.
+func (s *Server) ZeebeClients(clusterID string) []cc.ZeebeClientResponse {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	clients := []cc.ZeebeClientResponse{}
+	if state := s.findCluster(clusterID); state != nil {
+		for _, zc := range state.clients {
+			clients = append(clients, zc.client)
+		}
+	}
+	return clients
+}
+
+// AddConnectorSecret stores a connector secret for a cluster, as if it had been created earlier.
+func (s *Server) AddConnectorSecret(clusterID string, name string, value string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state := s.findCluster(clusterID)
+	if state == nil {
+		return fmt.Errorf("unknown cluster %q", clusterID)
+	}
+	if state.secrets == nil {
+		state.secrets = map[string]string{}
+	}
+	state.secrets[name] = value
+	return nil
+}
+
+// ConnectorSecrets returns the connector secrets stored for a cluster.
+func (s *Server) ConnectorSecrets(clusterID string) map[string]string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	secrets := map[string]string{}
+	if state := s.findCluster(clusterID); state != nil {
+		for name, value := range state.secrets {
+			secrets[name] = value
+		}
+	}
+	return secrets
+}
+
+// AddMember stores an organization member.
+func (s *Server) AddMember(member cc.OrganizationMember) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.members = append(s.members, member)
+}
+
+// Members returns the organization members currently stored.
+func (s *Server) Members() []cc.OrganizationMember {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]cc.OrganizationMember{}, s.members...)
+}
+
+func (s *Server) registerRoutes() {
+	s.handle("POST /oauth/token", false, s.token)
+	s.handle("GET /clusters/parameters", true, s.getParams)
+	s.handle("GET /clusters", true, s.getClusters)
+	s.handle("POST /clusters", true, s.createCluster)
+	s.handle("GET /clusters/{clusterId}", true, s.getCluster)
+	s.handle("DELETE /clusters/{clusterId}", true, s.deleteCluster)
+	s.handle("GET /clusters/{clusterId}/clients", true, s.getZeebeClients)
+	s.handle("POST /clusters/{clusterId}/clients", true, s.createZeebeClient)
+	s.handle("GET /clusters/{clusterId}/clients/{clientId}", true, s.getZeebeClient)
+	s.handle("PUT /clusters/{clusterId}/clients/{clientId}", true, s.updateZeebeClient)
+	s.handle("DELETE /clusters/{clusterId}/clients/{clientId}", true, s.deleteZeebeClient)
+	s.handle("PUT /clusters/{clusterId}/generation", true, s.upgradeCluster)
+	s.handle("PUT /clusters/{clusterId}/ipwhitelist", true, s.setIPAllowlist)
+	s.handle("GET /clusters/{clusterId}/secrets", true, s.getConnectorSecrets)
+	s.handle("POST /clusters/{clusterId}/secrets", true, s.createConnectorSecret)
+	s.handle("GET /members", true, s.getMembers)
+	s.handle("POST /members/{email}", true, s.inviteMember)
+	s.handle("PUT /members/{email}", true, s.updateMember)
+	s.handle("DELETE /members/{email}", true, s.deleteMember)
+}
+
+func (s *Server) token(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	payload := cc.AuthRequestPayload{}
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		http.Error(w, "invalid token request", http.StatusBadRequest)
+		return
+	}
+
+	if payload.ClientId == "" || payload.ClientSecret == "" ||
+		(s.ClientID != "" && (payload.ClientId != s.ClientID || payload.ClientSecret != s.ClientSecret)) {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	s.mu.Lock()
+	expiry := s.Now().Add(s.TokenTTL)
+	token := accessToken(expiry)
+	s.tokens[token] = expiry
+	scope := s.Scope
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, cc.AuthResponsePayload{
+		AccessToken: token,
+		Scope:       scope,
+		ExpiresIn:   int(s.TokenTTL.Seconds()),
+		TokenType:   "Bearer",
+	})
+}
+
+func (s *Server) authorized(r *http.Request) bool {
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	expiry, ok := s.tokens[token]
+	return ok && s.Now().Before(expiry)
+}
+
+func (s *Server) getParams(w http.ResponseWriter, _ *http.Request, _ map[string]string) {
+	writeJSON(w, http.StatusOK, s.Params())
+}
+
+func (s *Server) getClusters(w http.ResponseWriter, _ *http.Request, _ map[string]string) {
+	clusters := s.Clusters()
+	if s.OmitListingStatus {
+		for i := range clusters {
+			clusters[i].Status = cc.ClusterStatus{}
+			clusters[i].Links = cc.ClusterLinks{}
+		}
+	}
+	writeJSON(w, http.StatusOK, clusters)
+}
+
+func (s *Server) createCluster(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	payload := cc.ClusterCreationParams{}
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		http.Error(w, "invalid cluster creation request", http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cluster, err := s.newCluster(payload)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	s.clusters = append(s.clusters, &clusterState{cluster: cluster})
+
+	writeJSON(w, http.StatusOK, cc.ClusterCreatedResponse{ClusterId: cluster.ID})
+}
+
+func (s *Server) newCluster(payload cc.ClusterCreationParams) (cc.Cluster, error) {
+	if payload.ClusterName == "" {
+		return cc.Cluster{}, fmt.Errorf("name is required")
+	}
+
+	cluster := cc.Cluster{ID: s.nextID(), Name: payload.ClusterName, Created: s.Now().UTC()}
+
+	found := false
+	for _, channel := range s.params.Channels {
+		if channel.Id != payload.ChannelId {
+			continue
+		}
+		for _, generation := range channel.AllowedGeneration {
+			if generation.Id == payload.GenerationId {
+				cluster.Channel = channel
+				cluster.Generation = generation
+				found = true
+			}
+		}
+	}
+	if !found {
+		return cc.Cluster{}, fmt.Errorf("unknown channel %q or generation %q", payload.ChannelId, payload.GenerationId)
+	}
+
+	found = false
+	for _, plan := range s.params.ClusterPlanTypes {
+		if plan.Id == payload.PlanTypeId {
+			cluster.ClusterPlantType = plan
+			found = true
+		}
+	}
+	if !found {
+		return cc.Cluster{}, fmt.Errorf("unknown plan type %q", payload.PlanTypeId)
+	}
+
+	found = false
+	for _, region := range s.params.Regions {
+		if region.Id == payload.RegionId {
+			cluster.K8sContext = cc.K8sContext{UUID: region.Id, Name: region.Name}
+			found = true
+		}
+	}
+	if !found {
+		return cc.Cluster{}, fmt.Errorf("unknown region %q", payload.RegionId)
+	}
+
+	cluster.Status = statusFor(cc.HealthCreating)
+	fillLinks(&cluster)
+	return cluster, nil
+}
+
+// getCluster answers with the cluster and advances its lifecycle:
+// Creating and Updating clusters become Healthy and Deleting clusters disappear after enough polls.
+func (s *Server) getCluster(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	s.mu.Lock()
+	state := s.findCluster(params["clusterId"])
+	if state == nil {
+		s.mu.Unlock()
+		http.Error(w, "cluster not found", http.StatusNotFound)
+		return
+	}
+
+	cluster := state.cluster
+	if state.upgradeStarting > 0 {
+		state.upgradeStarting--
+		if state.upgradeStarting == 0 {
+			state.cluster.Status = statusFor(cc.HealthUpdating)
+		}
+		s.mu.Unlock()
+		writeJSON(w, http.StatusOK, cluster)
+		return
+	}
+	state.polls++
+	switch cluster.Status.Ready {
+	case cc.HealthCreating:
+		if state.polls >= s.CreatingPolls {
+			state.cluster.Status = statusFor(cc.HealthHealthy)
+			fillLinks(&state.cluster)
+			state.polls = 0
+		}
+	case cc.HealthUpdating:
+		if state.polls >= s.UpdatingPolls {
+			state.cluster.Status = statusFor(cc.HealthHealthy)
+			fillLinks(&state.cluster)
+			state.polls = 0
+		}
+	case cc.HealthDeleting:
+		if state.polls >= s.DeletingPolls {
+			s.removeCluster(cluster.ID)
+		}
+	}
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, cluster)
+}
+
+func (s *Server) deleteCluster(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state := s.findCluster(params["clusterId"])
+	if state == nil {
+		http.Error(w, "cluster not found", http.StatusNotFound)
+		return
+	}
+
+	state.cluster.Status = statusFor(cc.HealthDeleting)
+	state.polls = 0
+	if s.DeletingPolls <= 0 {
+		s.removeCluster(state.cluster.ID)
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) getZeebeClients(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	s.mu.Lock()
+	state := s.findCluster(params["clusterId"])
+	s.mu.Unlock()
+
+	if state == nil {
+		http.Error(w, "cluster not found", http.StatusNotFound)
+		return
+	}
+	writeJSON(w, http.StatusOK, s.ZeebeClients(params["clusterId"]))
+}
+
+func (s *Server) createZeebeClient(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	payload := cc.ZeebeClientCreatePayload{}
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil || payload.ClientName == "" {
+		http.Error(w, "invalid client creation request", http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state := s.findCluster(params["clusterId"])
+	if state == nil {
+		http.Error(w, "cluster not found", http.StatusNotFound)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, s.newZeebeClient(state, payload.ClientName, payload.Permissions))
+}
+
+// AddZeebeClient stores a Zeebe client for a cluster, as if it had been created earlier.
+func (s *Server) AddZeebeClient(clusterID string, name string, scopes ...cc.ZeebeClientScope) (cc.ZeebeClientCreatedResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state := s.findCluster(clusterID)
+	if state == nil {
+		return cc.ZeebeClientCreatedResponse{}, fmt.Errorf("unknown cluster %q", clusterID)
+	}
+	return s.newZeebeClient(state, name, scopes), nil
+}
+
+func (s *Server) newZeebeClient(state *clusterState, name string, permissions []cc.ZeebeClientScope) cc.ZeebeClientCreatedResponse {
+	if len(permissions) == 0 {
+		permissions = []cc.ZeebeClientScope{cc.ZeebeClientScopeZeebe, cc.ZeebeClientScopeOperate, cc.ZeebeClientScopeTasklist}
+	}
+
+	uuid := s.nextID()
+	zc := zeebeClientState{
+		client: cc.ZeebeClientResponse{
+			ClientID:    fmt.Sprintf("cctest-client-%d", s.idCounter),
+			Created:     s.Now().UTC(),
+			CreatedBy:   s.CreatedBy,
+			UUID:        uuid,
+			Name:        name,
+			Permissions: permissions,
+		},
+		secret: fmt.Sprintf("cctest-secret-%d", s.idCounter),
+	}
+	state.clients = append(state.clients, zc)
+
+	return cc.ZeebeClientCreatedResponse{
+		Name:         zc.client.Name,
+		ClientID:     zc.client.ClientID,
+		ClientSecret: zc.secret,
+	}
+}
+
+func (s *Server) getZeebeClient(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, zc := s.findZeebeClient(params["clusterId"], params["clientId"])
+	if zc == nil {
+		http.Error(w, "client not found", http.StatusNotFound)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, cc.ZeebeClientDetailsResponse{
+		Name:                        zc.client.Name,
+		ZEEBEADDRESS:                state.cluster.Links.Zeebe,
+		ZEEBECLIENTID:               zc.client.ClientID,
+		ZEEBEAUTHORIZATIONSERVERURL: s.URL + "/oauth/token",
+	})
+}
+
+func (s *Server) updateZeebeClient(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	payload := cc.ZeebeClientUpdatePayload{}
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil || len(payload.Permissions) == 0 {
+		http.Error(w, "invalid client update request", http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, zc := s.findZeebeClient(params["clusterId"], params["clientId"])
+	if zc == nil {
+		http.Error(w, "client not found", http.StatusNotFound)
+		return
+	}
+	zc.client.Permissions = payload.Permissions
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) deleteZeebeClient(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, zc := s.findZeebeClient(params["clusterId"], params["clientId"])
+	if zc == nil {
+		http.Error(w, "client not found", http.StatusNotFound)
+		return
+	}
+	remaining := []zeebeClientState{}
+	for _, other := range state.clients {
+		if other.client.ClientID != zc.client.ClientID {
+			remaining = append(remaining, other)
+		}
+	}
+	state.clients = remaining
+	w.WriteHeader(http.StatusOK)
+}
+
+// upgradeCluster moves the cluster to another generation of its channel, it reports Updating until polled enough.
+func (s *Server) upgradeCluster(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	payload := cc.UpgradeClusterPayload{}
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil || payload.GenerationID == "" {
+		http.Error(w, "invalid cluster upgrade request", http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state := s.findCluster(params["clusterId"])
+	if state == nil {
+		http.Error(w, "cluster not found", http.StatusNotFound)
+		return
+	}
+	for _, channel := range s.params.Channels {
+		if channel.Id != state.cluster.Channel.Id {
+			continue
+		}
+		for _, generation := range channel.AllowedGeneration {
+			if generation.Id == payload.GenerationID {
+				state.cluster.Generation = generation
+				state.polls = 0
+				if s.InstantUpgrades {
+					state.upgradeStarting = 0
+				} else if state.upgradeStarting = s.UpgradeStartPolls; state.upgradeStarting == 0 {
+					state.cluster.Status = statusFor(cc.HealthUpdating)
+				}
+				fillLinks(&state.cluster)
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+		}
+	}
+	http.Error(w, "generation not allowed on the channel of the cluster", http.StatusBadRequest)
+}
+
+func (s *Server) setIPAllowlist(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	payload := cc.IPAllowlistPayload{}
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		http.Error(w, "invalid IP allowlist request", http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state := s.findCluster(params["clusterId"])
+	if state == nil {
+		http.Error(w, "cluster not found", http.StatusNotFound)
+		return
+	}
+	state.cluster.IPAllowlist = payload.IPAllowlist
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) getConnectorSecrets(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	s.mu.Lock()
+	state := s.findCluster(params["clusterId"])
+	s.mu.Unlock()
+
+	if state == nil {
+		http.Error(w, "cluster not found", http.StatusNotFound)
+		return
+	}
+	writeJSON(w, http.StatusOK, s.ConnectorSecrets(params["clusterId"]))
+}
+
+func (s *Server) createConnectorSecret(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	payload := cc.ConnectorSecretPayload{}
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil || payload.SecretName == "" {
+		http.Error(w, "invalid secret creation request", http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state := s.findCluster(params["clusterId"])
+	if state == nil {
+		http.Error(w, "cluster not found", http.StatusNotFound)
+		return
+	}
+	if _, exists := state.secrets[payload.SecretName]; exists {
+		http.Error(w, "secret already exists", http.StatusConflict)
+		return
+	}
+	if state.secrets == nil {
+		state.secrets = map[string]string{}
+	}
+	state.secrets[payload.SecretName] = payload.SecretValue
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) getMembers(w http.ResponseWriter, _ *http.Request, _ map[string]string) {
+	writeJSON(w, http.StatusOK, s.Members())
+}
+
+func (s *Server) inviteMember(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	payload := cc.MemberRolesPayload{}
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil || len(payload.OrgRoles) == 0 {
+		http.Error(w, "invalid invitation request", http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.findMember(params["email"]) != nil {
+		http.Error(w, "member already exists", http.StatusConflict)
+		return
+	}
+	s.members = append(s.members, cc.OrganizationMember{Email: params["email"], Roles: payload.OrgRoles, InvitePending: true})
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) updateMember(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	payload := cc.MemberRolesPayload{}
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil || len(payload.OrgRoles) == 0 {
+		http.Error(w, "invalid member update request", http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	member := s.findMember(params["email"])
+	if member == nil {
+		http.Error(w, "member not found", http.StatusNotFound)
+		return
+	}
+	member.Roles = payload.OrgRoles
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) deleteMember(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.findMember(params["email"]) == nil {
+		http.Error(w, "member not found", http.StatusNotFound)
+		return
+	}
+	remaining := []cc.OrganizationMember{}
+	for _, member := range s.members {
+		if member.Email != params["email"] {
+			remaining = append(remaining, member)
+		}
+	}
+	s.members = remaining
+	w.WriteHeader(http.StatusOK)
+}
+
+// The helpers below expect s.mu to be held.
+
+func (s *Server) findCluster(clusterID string) *clusterState {
+	for _, state := range s.clusters {
+		if state.cluster.ID == clusterID {
+			return state
+		}
+	}
+	return nil
+}
+
+func (s *Server) removeCluster(clusterID string) {
+	remaining := []*clusterState{}
+	for _, state := range s.clusters {
+		if state.cluster.ID != clusterID {
+			remaining = append(remaining, state)
+		}
+	}
+	s.clusters = remaining
+}
+
+func (s *Server) findZeebeClient(clusterID string, clientID string) (*clusterState, *zeebeClientState) {
+	state := s.findCluster(clusterID)
+	if state == nil {
+		return nil, nil
+	}
+	for i := range state.clients {
+		if state.clients[i].client.ClientID == clientID {
+			return state, &state.clients[i]
+		}
+	}
+	return state, nil
+}
+
+func (s *Server) findMember(email string) *cc.OrganizationMember {
+	for i := range s.members {
+		if s.members[i].Email == email {
+			return &s.members[i]
+		}
+	}
+	return nil
+}
+
+// nextID returns predictable, uuid shaped ids so that tests can compare outputs.
+func (s *Server) nextID() string {
+	s.idCounter++
+	return fmt.Sprintf("00000000-0000-4000-8000-%012d", s.idCounter)
+}
+
+func statusFor(health cc.Health) cc.ClusterStatus {
+	return cc.ClusterStatus{
+		Ready:          health,
+		ZeebeStatus:    health,
+		OperateStatus:  health,
+		TaskListStatus: health,
+	}
+}
+
+func fillLinks(cluster *cc.Cluster) {
+	region := cluster.K8sContext.UUID
+	if region == "" {
+		region = "local"
+	}
+	cluster.Links = cc.ClusterLinks{
+		Zeebe:    cluster.ID + "." + region + ".zeebe.camunda.io:443",
+		Operate:  "https://" + region + ".operate.camunda.io/" + cluster.ID,
+		Tasklist: "https://" + region + ".tasklist.camunda.io/" + cluster.ID,
+	}
+	cluster.Status.ZeebeURL = cluster.Links.Zeebe
+	cluster.Status.OperateURL = cluster.Links.Operate
+	cluster.Status.TaskListURL = cluster.Links.Tasklist
+}
+
+func randomToken() string {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return fmt.Sprintf("%032d", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(buf)
+}
+
+// accessToken returns an unsigned JWT with the expiry in its exp claim, as the login server
+// issues signed ones, and a random id so that every token is different.
+func accessToken(expiry time.Time) string {
+	header, _ := json.Marshal(map[string]string{"alg": "none", "typ": "JWT"})
+	claims, _ := json.Marshal(map[string]interface{}{"exp": expiry.Unix(), "jti": randomToken()})
+	return base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims) + "."
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
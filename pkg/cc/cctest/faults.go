@@ -0,0 +1,163 @@
+package cctest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"time"
+)
+
+// AllRoutes can be used instead of a route pattern to inject a fault on every route.
+const AllRoutes = "*"
+
+// Fault makes a route misbehave. Its effects combine: a fault can for example add
+// latency and then answer with a truncated body.
+type Fault struct {
+	// Times is the number of requests the fault applies to. Zero applies it until cleared.
+	Times int
+
+	// Latency delays the answer.
+	Latency time.Duration
+
+	// ResetConnection closes the connection without answering.
+	ResetConnection bool
+
+	// Status answers with this status code, and Body, instead of calling the route.
+	Status int
+	Body   string
+
+	// RetryAfter sets the Retry-After header, typically together with Status 429.
+	RetryAfter time.Duration
+
+	// TruncateBody cuts the answer of the route in half, leaving invalid JSON.
+	TruncateBody bool
+
+	// MalformedJSON replaces the answer of the route with a body that is not JSON.
+	MalformedJSON bool
+}
+
+// Fail answers the next requests with the given status code.
+func Fail(status int, times int) Fault {
+	return Fault{Status: status, Times: times}
+}
+
+// RateLimit answers the next requests with 429 Too Many Requests and a Retry-After header.
+func RateLimit(retryAfter time.Duration, times int) Fault {
+	return Fault{Status: http.StatusTooManyRequests, RetryAfter: retryAfter, Times: times}
+}
+
+type injectedFault struct {
+	fault     Fault
+	remaining int
+}
+
+// InjectFault makes a route, for example "GET /clusters/{clusterId}", misbehave.
+// Faults are applied in the order they were injected, one per request.
+// It panics when the route does not exist, to catch typos in tests.
+func (s *Server) InjectFault(routePattern string, fault Fault) {
+	if routePattern != AllRoutes && !s.hasRoute(routePattern) {
+		panic("cctest: unknown route " + routePattern)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.faults == nil {
+		s.faults = map[string][]*injectedFault{}
+	}
+	s.faults[routePattern] = append(s.faults[routePattern], &injectedFault{fault: fault, remaining: fault.Times})
+}
+
+// ClearFaults removes every injected fault.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = nil
+}
+
+// ExpireTokens makes every access token issued so far invalid, while clients still
+// believe them valid according to the expires_in they were given.
+func (s *Server) ExpireTokens() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for token := range s.tokens {
+		s.tokens[token] = time.Time{}
+	}
+}
+
+func (s *Server) hasRoute(routePattern string) bool {
+	for _, rt := range s.routes {
+		if rt.pattern == routePattern {
+			return true
+		}
+	}
+	return false
+}
+
+// nextFault returns the fault to apply to a request on the route, if any.
+func (s *Server) nextFault(routePattern string) *Fault {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, pattern := range []string{routePattern, AllRoutes} {
+		for _, injected := range s.faults[pattern] {
+			if injected.fault.Times > 0 && injected.remaining == 0 {
+				continue
+			}
+			if injected.fault.Times > 0 {
+				injected.remaining--
+			}
+			fault := injected.fault
+			return &fault
+		}
+	}
+	return nil
+}
+
+// serveWithFault answers the request according to the fault, calling next when
+// the fault needs the regular answer of the route.
+func serveWithFault(w http.ResponseWriter, r *http.Request, fault *Fault, next func(w http.ResponseWriter)) {
+	if fault.Latency > 0 {
+		select {
+		case <-time.After(fault.Latency):
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	if fault.ResetConnection {
+		// Aborts the handler and closes the connection without a response.
+		panic(http.ErrAbortHandler)
+	}
+
+	if fault.RetryAfter > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(int(fault.RetryAfter.Seconds())))
+	}
+
+	if fault.Status != 0 {
+		w.WriteHeader(fault.Status)
+		w.Write([]byte(fault.Body))
+		return
+	}
+
+	if !fault.TruncateBody && !fault.MalformedJSON {
+		next(w)
+		return
+	}
+
+	recorder := httptest.NewRecorder()
+	next(recorder)
+
+	body := recorder.Body.Bytes()
+	if fault.TruncateBody {
+		body = body[:len(body)/2]
+	}
+	if fault.MalformedJSON {
+		body = []byte("<html>Service Unavailable</html>")
+	}
+
+	for key, values := range recorder.Header() {
+		w.Header()[key] = values
+	}
+	w.WriteHeader(recorder.Code)
+	w.Write(body)
+}
@@ -0,0 +1,161 @@
+package cctest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	cc "github.com/camunda-community-hub/camunda-cloud-go-client/pkg/cc/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Fault_failuresThenRecovery(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	ccClient := srv.Client()
+	srv.InjectFault("GET /members", Fail(http.StatusServiceUnavailable, 2))
+
+	for i := 0; i < 2; i++ {
+		_, err := ccClient.GetMembers()
+		httpErr := &cc.HTTPError{}
+		assert.True(t, errors.As(err, &httpErr))
+		assert.Equal(t, http.StatusServiceUnavailable, httpErr.StatusCode)
+	}
+
+	_, err := ccClient.GetMembers()
+	assert.NoError(t, err)
+}
+
+func Test_Fault_rateLimit(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	srv.InjectFault(AllRoutes, RateLimit(30*time.Second, 1))
+
+	resp, err := http.Post(srv.URL+"/oauth/token", "application/json", nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, "30", resp.Header.Get("Retry-After"))
+}
+
+func Test_Fault_latency(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	ccClient := srv.Client()
+	srv.InjectFault("GET /members", Fault{Latency: time.Second})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := ccClient.GetMembersWithContext(ctx)
+
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}
+
+func Test_Fault_brokenBodies(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	ccClient := srv.Client()
+	cluster := srv.AddCluster(cc.Cluster{Name: "broken"})
+
+	srv.InjectFault("GET /clusters", Fault{TruncateBody: true, Times: 1})
+	_, err := ccClient.GetClusters()
+	assert.Error(t, err)
+
+	srv.InjectFault("GET /clusters/{clusterId}", Fault{MalformedJSON: true, Times: 1})
+	status, err := ccClient.GetClusterDetails(cluster.ID)
+	assert.Error(t, err, "a broken body is not a missing cluster")
+	assert.Empty(t, status.Ready)
+
+	// the transport transparently retries a GET once when a reused connection is reset
+	srv.InjectFault("GET /clusters", Fault{ResetConnection: true})
+	_, err = ccClient.GetClusters()
+	assert.Error(t, err)
+	srv.ClearFaults()
+
+	clusters, err := ccClient.GetClusters()
+	assert.NoError(t, err)
+	assert.Len(t, clusters, 1)
+}
+
+func Test_Fault_failedRoutes(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	ccClient := srv.Client()
+	cluster := srv.AddCluster(cc.Cluster{Name: "orders"})
+	_, err := ccClient.GetClusterParams()
+	assert.NoError(t, err)
+
+	calls := map[string]func() error{
+		"GET /clusters/{clusterId}": func() error {
+			_, err := ccClient.GetClusterDetails(cluster.ID)
+			return err
+		},
+		"POST /clusters": func() error {
+			_, err := ccClient.CreateClusterDefault("payments")
+			return err
+		},
+		"POST /clusters/{clusterId}/clients": func() error {
+			_, err := ccClient.CreateZeebeClient(cluster.ID, "worker")
+			return err
+		},
+	}
+	for route, call := range calls {
+		for _, status := range []int{http.StatusBadRequest, http.StatusForbidden, http.StatusServiceUnavailable} {
+			srv.InjectFault(route, Fail(status, 1))
+			err := call()
+			httpErr := &cc.HTTPError{}
+			if assert.True(t, errors.As(err, &httpErr), "%s answering %d", route, status) {
+				assert.Equal(t, status, httpErr.StatusCode, route)
+			}
+		}
+		assert.NoError(t, call(), "%s once the faults are over", route)
+	}
+}
+
+func Test_Fault_expiredTokens(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	ccClient := srv.Client()
+
+	cluster := srv.AddCluster(cc.Cluster{Name: "orders"})
+	_, err := ccClient.GetClusterParams()
+	assert.NoError(t, err)
+
+	srv.ExpireTokens()
+
+	_, err = ccClient.GetMembers()
+	assert.EqualError(t, err, "HTTP Error trying to getMembers: 401")
+	_, err = ccClient.GetClusterDetails(cluster.ID)
+	assert.EqualError(t, err, "HTTP Error trying to getClusterDetails: 401")
+	_, err = ccClient.CreateClusterWithParams("payments", "Development", "Stable", "", "Europe West")
+	assert.EqualError(t, err, "HTTP Error trying to getClusters: 401", "the name is checked first")
+	_, err = ccClient.CreateClusterCustomConfig(cc.ClusterCreationParams{ClusterName: "payments"})
+	assert.EqualError(t, err, "HTTP Error trying to getClusters: 401")
+	_, err = ccClient.CreateZeebeClient(cluster.ID, "worker")
+	assert.EqualError(t, err, "HTTP Error trying to createZeebeClient: 401")
+
+	_, err = ccClient.Login("cctest", "cctest")
+	assert.NoError(t, err)
+	_, err = ccClient.GetMembers()
+	assert.NoError(t, err)
+}
+
+func Test_Fault_unknownRoute(t *testing.T) {
+	srv := New()
+
+	assert.Panics(t, func() { srv.InjectFault("GET /clusterz", Fail(500, 1)) })
+}
+
+func Test_ClearFaults(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	ccClient := srv.Client()
+	srv.InjectFault("GET /members", Fail(http.StatusInternalServerError, 0))
+
+	srv.ClearFaults()
+
+	_, err := ccClient.GetMembers()
+	assert.NoError(t, err)
+}
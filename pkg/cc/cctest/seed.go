@@ -0,0 +1,230 @@
+package cctest
+
+import (
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"strings"
+	"time"
+
+	cc "github.com/camunda-community-hub/camunda-cloud-go-client/pkg/cc/client"
+	"gopkg.in/yaml.v2"
+)
+
+// Seed describes the initial state of a server. Everything is referenced by name,
+// ids are derived from the names when they are not given.
+//
+//	channels:
+//	  - name: Stable
+//	    generations: [Zeebe 0.26.1, Zeebe 1.0.0]
+//	    defaultGeneration: Zeebe 1.0.0
+//	plans: [Development]
+//	regions: [Europe West]
+//	clusters:
+//	  - name: prod-eu
+//	    plan: Development
+//	    channel: Stable
+//	    region: Europe West
+//	    clients:
+//	      - name: worker
+//	        scopes: [Zeebe]
+type Seed struct {
+	Channels []SeedChannel `yaml:"channels"`
+	Plans    []string      `yaml:"plans"`
+	Regions  []string      `yaml:"regions"`
+	Clusters []SeedCluster `yaml:"clusters"`
+	Members  []SeedMember  `yaml:"members"`
+}
+
+type SeedChannel struct {
+	ID                string   `yaml:"id"`
+	Name              string   `yaml:"name"`
+	Generations       []string `yaml:"generations"`
+	DefaultGeneration string   `yaml:"defaultGeneration"`
+}
+
+type SeedCluster struct {
+	ID      string `yaml:"id"`
+	Name    string `yaml:"name"`
+	Plan    string `yaml:"plan"`
+	Channel string `yaml:"channel"`
+	// Generation defaults to the default generation of the channel.
+	Generation string `yaml:"generation"`
+	// Region defaults to the first region.
+	Region string `yaml:"region"`
+	// Status defaults to Healthy.
+	Status  cc.Health    `yaml:"status"`
+	Created time.Time    `yaml:"created"`
+	Clients []SeedClient `yaml:"clients"`
+}
+
+type SeedMember struct {
+	Name          string          `yaml:"name"`
+	Email         string          `yaml:"email"`
+	Roles         []cc.MemberRole `yaml:"roles"`
+	InvitePending bool            `yaml:"invitePending"`
+}
+
+type SeedClient struct {
+	Name   string                `yaml:"name"`
+	Scopes []cc.ZeebeClientScope `yaml:"scopes"`
+}
+
+// LoadSeed reads a seed from a YAML file.
+func LoadSeed(path string) (Seed, error) {
+	seed := Seed{}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return seed, err
+	}
+	if err := yaml.UnmarshalStrict(data, &seed); err != nil {
+		return seed, fmt.Errorf("invalid seed %s: %v", path, err)
+	}
+	return seed, nil
+}
+
+// ApplySeed adds the clusters and members of the seed. The channels, plans and regions
+// of the seed replace the default ones when the seed defines any.
+func (s *Server) ApplySeed(seed Seed) error {
+	params := s.Params()
+	if len(seed.Channels) > 0 || len(seed.Plans) > 0 || len(seed.Regions) > 0 {
+		var err error
+		if params, err = seed.params(); err != nil {
+			return err
+		}
+		s.SetParams(params)
+	}
+
+	for _, sc := range seed.Clusters {
+		cluster, err := sc.cluster(params)
+		if err != nil {
+			return err
+		}
+		cluster = s.AddCluster(cluster)
+		for _, client := range sc.Clients {
+			if _, err := s.AddZeebeClient(cluster.ID, client.Name, client.Scopes...); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, member := range seed.Members {
+		s.AddMember(cc.OrganizationMember{
+			Name:          member.Name,
+			Email:         member.Email,
+			Roles:         member.Roles,
+			InvitePending: member.InvitePending,
+		})
+	}
+	return nil
+}
+
+func (seed Seed) params() (cc.ClusterParams, error) {
+	params := cc.ClusterParams{
+		Channels:         []cc.Channel{},
+		ClusterPlanTypes: []cc.ClusterPlantType{},
+		Regions:          []cc.Region{},
+	}
+
+	for _, sc := range seed.Channels {
+		channel := cc.Channel{Id: idOrSlug(sc.ID, "channel", sc.Name), Name: sc.Name, AllowedGeneration: []cc.Generation{}}
+		for _, name := range sc.Generations {
+			channel.AllowedGeneration = append(channel.AllowedGeneration, cc.Generation{Id: idOrSlug("", "generation", name), Name: name})
+		}
+		if len(channel.AllowedGeneration) == 0 {
+			return params, fmt.Errorf("channel %s has no generations", sc.Name)
+		}
+		channel.DefaultGeneration = channel.AllowedGeneration[len(channel.AllowedGeneration)-1]
+		if sc.DefaultGeneration != "" {
+			found := false
+			for _, generation := range channel.AllowedGeneration {
+				if generation.Name == sc.DefaultGeneration {
+					channel.DefaultGeneration = generation
+					found = true
+				}
+			}
+			if !found {
+				return params, fmt.Errorf("default generation %s is not a generation of channel %s", sc.DefaultGeneration, sc.Name)
+			}
+		}
+		params.Channels = append(params.Channels, channel)
+	}
+
+	for _, name := range seed.Plans {
+		params.ClusterPlanTypes = append(params.ClusterPlanTypes, cc.ClusterPlantType{Id: idOrSlug("", "plan", name), Name: name})
+	}
+
+	for _, name := range seed.Regions {
+		params.Regions = append(params.Regions, cc.Region{Id: idOrSlug("", "region", name), Name: name})
+	}
+
+	return params, nil
+}
+
+func (sc SeedCluster) cluster(params cc.ClusterParams) (cc.Cluster, error) {
+	cluster := cc.Cluster{ID: sc.ID, Name: sc.Name, Created: sc.Created}
+	if sc.Status != "" {
+		cluster.Status = statusFor(sc.Status)
+	}
+
+	found := false
+	for _, channel := range params.Channels {
+		if channel.Name == sc.Channel {
+			cluster.Channel = channel
+			cluster.Generation = channel.DefaultGeneration
+			found = true
+		}
+	}
+	if !found {
+		return cluster, fmt.Errorf("cluster %s: unknown channel %q", sc.Name, sc.Channel)
+	}
+
+	if sc.Generation != "" {
+		found = false
+		for _, generation := range cluster.Channel.AllowedGeneration {
+			if generation.Name == sc.Generation {
+				cluster.Generation = generation
+				found = true
+			}
+		}
+		if !found {
+			return cluster, fmt.Errorf("cluster %s: unknown generation %q", sc.Name, sc.Generation)
+		}
+	}
+
+	found = false
+	for _, plan := range params.ClusterPlanTypes {
+		if plan.Name == sc.Plan {
+			cluster.ClusterPlantType = plan
+			found = true
+		}
+	}
+	if !found {
+		return cluster, fmt.Errorf("cluster %s: unknown plan %q", sc.Name, sc.Plan)
+	}
+
+	if sc.Region == "" && len(params.Regions) > 0 {
+		sc.Region = params.Regions[0].Name
+	}
+	found = false
+	for _, region := range params.Regions {
+		if region.Name == sc.Region {
+			cluster.K8sContext = cc.K8sContext{UUID: region.Id, Name: region.Name}
+			found = true
+		}
+	}
+	if !found {
+		return cluster, fmt.Errorf("cluster %s: unknown region %q", sc.Name, sc.Region)
+	}
+
+	return cluster, nil
+}
+
+var nonSlugCharacters = regexp.MustCompile(`[^a-z0-9]+`)
+
+func idOrSlug(id string, kind string, name string) string {
+	if id != "" {
+		return id
+	}
+	return kind + "-" + strings.Trim(nonSlugCharacters.ReplaceAllString(strings.ToLower(name), "-"), "-")
+}
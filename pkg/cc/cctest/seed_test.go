@@ -0,0 +1,47 @@
+package cctest
+
+import (
+	"testing"
+	"time"
+
+	cc "github.com/camunda-community-hub/camunda-cloud-go-client/pkg/cc/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ApplySeed(t *testing.T) {
+	seed, err := LoadSeed("testdata/seed.yaml")
+	assert.NoError(t, err)
+
+	srv := NewServer()
+	defer srv.Close()
+	assert.NoError(t, srv.ApplySeed(seed))
+
+	clusters, err := srv.Client().GetClusters()
+	assert.NoError(t, err)
+	assert.Len(t, clusters, 2)
+
+	prod := clusters[0]
+	assert.Equal(t, "prod-eu", prod.Name)
+	assert.Equal(t, "Production - S", prod.ClusterPlantType.Name)
+	assert.Equal(t, "generation-zeebe-1-0-0", prod.Generation.Id)
+	assert.Equal(t, "Europe West", prod.K8sContext.Name)
+	assert.Equal(t, time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC), prod.Created.UTC())
+	assert.Equal(t, cc.HealthHealthy, prod.Health())
+	assert.Len(t, srv.ZeebeClients(prod.ID), 1)
+
+	legacy := clusters[1]
+	assert.Equal(t, "Zeebe 0.26.1", legacy.Generation.Name)
+	assert.Equal(t, "US East", legacy.K8sContext.Name)
+	assert.Equal(t, cc.HealthSuspended, legacy.Health())
+
+	assert.Equal(t, []cc.MemberRole{cc.MemberRoleAdmin}, srv.Members()[0].Roles)
+	assert.Len(t, srv.Params().Channels, 2)
+}
+
+func Test_ApplySeed_unknownReference(t *testing.T) {
+	srv := New()
+
+	err := srv.ApplySeed(Seed{Clusters: []SeedCluster{{Name: "x", Plan: "Development", Channel: "Nightly"}}})
+
+	assert.EqualError(t, err, `cluster x: unknown channel "Nightly"`)
+}
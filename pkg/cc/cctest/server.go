@@ -0,0 +1,278 @@
+// Package cctest provides an in-memory stand-in of the Camunda Cloud management
+// and OAuth APIs, to test code that uses the client package without a live account.
+// Faults such as latency, error statuses or broken bodies can be injected per route
+// to test error handling.
+//
+//	srv := cctest.NewServer()
+//	defer srv.Close()
+//
+//	ccClient := srv.Client()
+//	clusterID, _ := ccClient.CreateClusterDefault("my-cluster")
+package cctest
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	cc "github.com/camunda-community-hub/camunda-cloud-go-client/pkg/cc/client"
+)
+
+// Request is a request received by the server.
+type Request struct {
+	// Route is the route pattern the request matched, for example "GET /clusters/{clusterId}".
+	// It is empty when no route matched.
+	Route  string
+	Method string
+	Path   string
+	Params map[string]string
+	Header http.Header
+	Body   []byte
+}
+
+// Server serves the Camunda Cloud API from in-memory state.
+// A Server is an http.Handler; NewServer also starts it on a local port.
+type Server struct {
+	// URL of the started server, empty when the handler is served by other means.
+	URL string
+
+	// ClientID and ClientSecret are the only credentials accepted by the token endpoint.
+	// When left empty any non empty credentials are accepted.
+	ClientID     string
+	ClientSecret string
+
+	// Scope is returned as the scope granted to the access tokens, every scope of the
+	// client by default. An empty scope gives tokens that do not list their scopes.
+	Scope string
+
+	// TokenTTL is the lifetime of the access tokens.
+	TokenTTL time.Duration
+
+	// CreatingPolls, UpdatingPolls and DeletingPolls are the number of cluster detail
+	// requests a cluster keeps reporting Creating, Updating or Deleting before it moves on.
+	CreatingPolls int
+	UpdatingPolls int
+	DeletingPolls int
+
+	// UpgradeStartPolls is the number of cluster detail requests an upgraded cluster keeps
+	// reporting its previous status on the new generation before reporting Updating, as the
+	// API does until the upgrade actually starts. Zero reports Updating right away.
+	UpgradeStartPolls int
+
+	// InstantUpgrades makes upgraded clusters report their previous status on the new generation
+	// and never Updating, as when the upgrade finishes between two polls.
+	InstantUpgrades bool
+
+	// CreatedBy is the user reported as creator of the Zeebe clients created next, cctest by default.
+	CreatedBy string
+
+	// OmitListingStatus leaves the status and links out of the cluster listing,
+	// so that they are only available through the cluster details.
+	OmitListingStatus bool
+
+	// Now is the clock used for timestamps and token expiry.
+	Now func() time.Time
+
+	httpServer *httptest.Server
+
+	mu        sync.Mutex
+	routes    []route
+	requests  []Request
+	tokens    map[string]time.Time
+	params    cc.ClusterParams
+	clusters  []*clusterState
+	members   []cc.OrganizationMember
+	faults    map[string][]*injectedFault
+	idCounter int
+}
+
+// New returns a server seeded with DefaultParams and no clusters, without starting it.
+func New() *Server {
+	s := &Server{
+		Scope:         DefaultScope(),
+		TokenTTL:      time.Hour,
+		CreatingPolls: 1,
+		UpdatingPolls: 1,
+		CreatedBy:     "cctest",
+		DeletingPolls: 1,
+		Now:           time.Now,
+		tokens:        map[string]time.Time{},
+		params:        DefaultParams(),
+	}
+	s.registerRoutes()
+	return s
+}
+
+// DefaultScope grants every scope known to the client.
+func DefaultScope() string {
+	names := []string{}
+	for _, scope := range cc.Scopes {
+		names = append(names, string(scope))
+	}
+	return strings.Join(names, " ")
+}
+
+// NewServer starts a new server on a local port. Callers should Close it when done.
+func NewServer() *Server {
+	s := New()
+	s.httpServer = httptest.NewServer(s)
+	s.URL = s.httpServer.URL
+	return s
+}
+
+// Close shuts the started server down.
+func (s *Server) Close() {
+	if s.httpServer != nil {
+		s.httpServer.Close()
+	}
+}
+
+// Configure points a client at this server.
+func (s *Server) Configure(ccClient *cc.CCClient) {
+	ccClient.SetAPIURL(s.URL)
+	ccClient.SetLoginURL(s.URL)
+}
+
+// Client returns a client pointed at this server, logged in and with the cluster params loaded.
+// It panics when the server refuses the login.
+func (s *Server) Client() *cc.CCClient {
+	ccClient := &cc.CCClient{}
+	s.Configure(ccClient)
+
+	id, secret := s.ClientID, s.ClientSecret
+	if id == "" {
+		id, secret = "cctest", "cctest"
+	}
+	if _, err := ccClient.Login(id, secret); err != nil {
+		panic(fmt.Sprintf("cctest: login failed: %v", err))
+	}
+	if _, err := ccClient.GetClusterParams(); err != nil {
+		panic(fmt.Sprintf("cctest: loading cluster params failed: %v", err))
+	}
+	return ccClient
+}
+
+// Requests returns the requests received so far, oldest first.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request{}, s.requests...)
+}
+
+// RequestsTo returns the requests received on a route, for example "POST /clusters".
+func (s *Server) RequestsTo(routePattern string) []Request {
+	matching := []Request{}
+	for _, r := range s.Requests() {
+		if r.Route == routePattern {
+			matching = append(matching, r)
+		}
+	}
+	return matching
+}
+
+// ResetRequests forgets the requests received so far.
+func (s *Server) ResetRequests() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = nil
+}
+
+// ServeHTTP records the request and dispatches it to the matching route.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := ioutil.ReadAll(r.Body)
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	rt, params := s.match(r)
+
+	recorded := Request{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Params: params,
+		Header: r.Header.Clone(),
+		Body:   body,
+	}
+	if rt != nil {
+		recorded.Route = rt.pattern
+	}
+	s.mu.Lock()
+	s.requests = append(s.requests, recorded)
+	s.mu.Unlock()
+
+	if rt == nil {
+		http.Error(w, "Not Found", http.StatusNotFound)
+		return
+	}
+
+	serve := func(w http.ResponseWriter) {
+		if rt.authenticated && !s.authorized(r) {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		rt.handler(w, r, params)
+	}
+
+	if fault := s.nextFault(rt.pattern); fault != nil {
+		serveWithFault(w, r, fault, serve)
+		return
+	}
+	serve(w)
+}
+
+type handlerFunc func(w http.ResponseWriter, r *http.Request, params map[string]string)
+
+type route struct {
+	pattern       string
+	method        string
+	segments      []string
+	authenticated bool
+	handler       handlerFunc
+}
+
+// handle registers a route. Patterns look like "GET /clusters/{clusterId}".
+func (s *Server) handle(pattern string, authenticated bool, handler handlerFunc) {
+	parts := strings.SplitN(pattern, " ", 2)
+	s.routes = append(s.routes, route{
+		pattern:       pattern,
+		method:        parts[0],
+		segments:      splitPath(parts[1]),
+		authenticated: authenticated,
+		handler:       handler,
+	})
+}
+
+func (s *Server) match(r *http.Request) (*route, map[string]string) {
+	segments := splitPath(r.URL.Path)
+	for i := range s.routes {
+		rt := &s.routes[i]
+		if rt.method != r.Method || len(rt.segments) != len(segments) {
+			continue
+		}
+		params := map[string]string{}
+		matched := true
+		for j, segment := range rt.segments {
+			if strings.HasPrefix(segment, "{") {
+				params[strings.Trim(segment, "{}")] = segments[j]
+			} else if segment != segments[j] {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return rt, params
+		}
+	}
+	return nil, nil
+}
+
+func splitPath(path string) []string {
+	trimmed := strings.Trim(path, "/")
+	if trimmed == "" {
+		return []string{}
+	}
+	return strings.Split(trimmed, "/")
+}
@@ -0,0 +1,91 @@
+package cctest
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	cc "github.com/camunda-community-hub/camunda-cloud-go-client/pkg/cc/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Server_recordsRequests(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	srv.Client()
+	resp, err := http.Get(srv.URL + "/unknown")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	requests := srv.Requests()
+	assert.Len(t, requests, 3)
+	assert.Equal(t, "POST /oauth/token", requests[0].Route)
+	assert.Equal(t, "GET /clusters/parameters", requests[1].Route)
+	assert.True(t, strings.HasPrefix(requests[1].Header.Get("Authorization"), "Bearer "))
+	assert.Equal(t, "", requests[2].Route)
+	assert.Equal(t, "/unknown", requests[2].Path)
+
+	srv.ResetRequests()
+	assert.Empty(t, srv.Requests())
+}
+
+func Test_Server_rejectsExpiredTokens(t *testing.T) {
+	now := time.Date(2021, 3, 5, 10, 0, 0, 0, time.UTC)
+	srv := NewServer()
+	defer srv.Close()
+	srv.Now = func() time.Time { return now }
+	srv.TokenTTL = time.Minute
+
+	ccClient := srv.Client()
+	now = now.Add(2 * time.Minute)
+
+	_, err := ccClient.GetMembers()
+	assert.EqualError(t, err, "HTTP Error trying to getMembers: 401")
+}
+
+func Test_Server_clusterStates(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	srv.CreatingPolls = 2
+	ccClient := srv.Client()
+
+	clusterID, err := ccClient.CreateClusterDefault("polled")
+	assert.NoError(t, err)
+
+	states := []cc.Health{}
+	for i := 0; i < 3; i++ {
+		status, _ := ccClient.GetClusterDetails(clusterID)
+		states = append(states, status.Ready)
+	}
+	ccClient.DeleteCluster(clusterID)
+	for i := 0; i < 2; i++ {
+		status, _ := ccClient.GetClusterDetails(clusterID)
+		states = append(states, status.Ready)
+	}
+
+	assert.Equal(t, []cc.Health{cc.HealthCreating, cc.HealthCreating, cc.HealthHealthy, cc.HealthDeleting, cc.HealthNotFound}, states)
+	assert.Empty(t, srv.Clusters())
+}
+
+func Test_Server_upgradeStartPolls(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	srv.UpgradeStartPolls = 2
+	ccClient := srv.Client()
+	stable := DefaultParams().Channels[0]
+	cluster := srv.AddCluster(cc.Cluster{Name: "upgraded", Channel: stable, Generation: stable.AllowedGeneration[0]})
+
+	_, err := ccClient.UpgradeCluster(cluster.ID, stable.AllowedGeneration[1].Id)
+	assert.NoError(t, err)
+
+	states := []cc.Health{}
+	for i := 0; i < 4; i++ {
+		upgraded, _ := ccClient.GetCluster(cluster.ID)
+		assert.Equal(t, stable.AllowedGeneration[1].Name, upgraded.Generation.Name)
+		states = append(states, upgraded.Status.Ready)
+	}
+
+	assert.Equal(t, []cc.Health{cc.HealthHealthy, cc.HealthHealthy, cc.HealthUpdating, cc.HealthHealthy}, states)
+}
@@ -0,0 +1,77 @@
+package client
+
+import (
+	"context"
+	"time"
+)
+
+//go:generate mockery --name CCAPI --output ./mocks --outpkg mocks --case underscore
+
+// AuthAPI authenticates against Camunda Cloud.
+type AuthAPI interface {
+	LoginWithContext(ctx context.Context, clientId string, clientSecret string) (bool, error)
+	HasScope(scope Scope) bool
+	RequireScopes(scopes ...Scope) error
+}
+
+// ClusterAPI manages the clusters of an organization.
+type ClusterAPI interface {
+	GetClusterParamsWithContext(ctx context.Context) (*ClusterParams, error)
+	GetClustersWithContext(ctx context.Context) ([]Cluster, error)
+	GetClustersWithStatus(ctx context.Context) ([]Cluster, error)
+	ListClusters(ctx context.Context, opts ListOptions) ([]Cluster, error)
+	GetClusterByNameWithContext(ctx context.Context, name string) (Cluster, error)
+	GetClusterWithContext(ctx context.Context, clusterID string) (Cluster, error)
+	GetClusterDetailsWithContext(ctx context.Context, clusterId string) (ClusterStatus, error)
+	WaitForClusterHealthy(ctx context.Context, clusterID string, interval time.Duration) (ClusterStatus, error)
+	CreateClusterDefaultWithContext(ctx context.Context, clusterName string) (string, error)
+	CreateClusterWithParamsAndContext(ctx context.Context, clusterName string, clusterPlanName string, channelName string, generationName string, clusterRegion string) (string, error)
+	CreateClusterCustomConfigWithContext(ctx context.Context, clusterParams ClusterCreationParams) (string, error)
+	CreateClusterFromSpec(ctx context.Context, spec ClusterSpec) (string, error)
+	EnsureCluster(ctx context.Context, spec ClusterSpec) (Cluster, bool, error)
+	DeleteClusterWithContext(ctx context.Context, clusterId string) (bool, error)
+	DeleteClustersMatching(ctx context.Context, filter ListOptions, opts GCOptions) (GCReport, error)
+	CloneCluster(ctx context.Context, sourceID string, newName string, opts CloneOptions) (CloneReport, error)
+	SetIPAllowlistWithContext(ctx context.Context, clusterID string, entries []IPAllowlistEntry) (bool, error)
+	UpgradeClusterWithContext(ctx context.Context, clusterID string, generationID string) (bool, error)
+	PlanRollout(ctx context.Context, filter ListOptions, target string) (RolloutState, error)
+	RolloutGeneration(ctx context.Context, state *RolloutState, opts RolloutOptions) error
+	Inventory(ctx context.Context, filter ListOptions, opts InventoryOptions) (InventoryReport, error)
+	SnapshotOrganization(ctx context.Context, name string) (OrganizationSnapshot, error)
+}
+
+// ZeebeClientAPI manages the Zeebe clients of a cluster.
+type ZeebeClientAPI interface {
+	GetZeebeClientsWithContext(ctx context.Context, clusterID string) ([]ZeebeClientResponse, error)
+	GetZeebeClientDetailsWithContext(ctx context.Context, clusterID string, clientID string) (ZeebeClientDetailsResponse, error)
+	CreateZeebeClientWithContext(ctx context.Context, clusterID string, clientName string, scopes ...ZeebeClientScope) (ZeebeClientCreatedResponse, error)
+	EnsureZeebeClient(ctx context.Context, clusterID string, name string, scopes ...ZeebeClientScope) (ZeebeClientCreatedResponse, bool, error)
+	UpdateZeebeClientWithContext(ctx context.Context, clusterID string, clientID string, scopes ...ZeebeClientScope) (bool, error)
+	DeleteZeebeClientWithContext(ctx context.Context, clusterID string, clientID string) (bool, error)
+}
+
+// SecretAPI manages the connector secrets of a cluster.
+type SecretAPI interface {
+	GetConnectorSecretsWithContext(ctx context.Context, clusterID string) (map[string]string, error)
+	CreateConnectorSecretWithContext(ctx context.Context, clusterID string, name string, value string) (bool, error)
+}
+
+// MemberAPI manages the members of an organization.
+type MemberAPI interface {
+	GetMembersWithContext(ctx context.Context) ([]OrganizationMember, error)
+	InviteMemberWithContext(ctx context.Context, email string, roles ...MemberRole) (bool, error)
+	UpdateMemberRolesWithContext(ctx context.Context, email string, roles ...MemberRole) (bool, error)
+	DeleteMemberWithContext(ctx context.Context, email string) (bool, error)
+}
+
+// CCAPI is everything CCClient offers, so that consumers can substitute it in tests.
+// A mock implementation is maintained in the mocks package.
+type CCAPI interface {
+	AuthAPI
+	ClusterAPI
+	ZeebeClientAPI
+	SecretAPI
+	MemberAPI
+}
+
+var _ CCAPI = &CCClient{}
@@ -0,0 +1,32 @@
+package client_test
+
+import (
+	"context"
+	"testing"
+
+	cc "github.com/camunda-community-hub/camunda-cloud-go-client/pkg/cc/client"
+	"github.com/camunda-community-hub/camunda-cloud-go-client/pkg/cc/client/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// createClusterWithWorker stands for consumer code written against the interfaces.
+func createClusterWithWorker(ctx context.Context, api cc.CCAPI, name string) (cc.ZeebeClientCreatedResponse, error) {
+	clusterID, err := api.CreateClusterDefaultWithContext(ctx, name)
+	if err != nil {
+		return cc.ZeebeClientCreatedResponse{}, err
+	}
+	return api.CreateZeebeClientWithContext(ctx, clusterID, name+"-worker", cc.ZeebeClientScopeZeebe)
+}
+
+func Test_CCAPI_mock(t *testing.T) {
+	api := mocks.NewCCAPI(t)
+	api.On("CreateClusterDefaultWithContext", mock.Anything, "orders").Return("cluster-1", nil)
+	api.On("CreateZeebeClientWithContext", mock.Anything, "cluster-1", "orders-worker", cc.ZeebeClientScopeZeebe).
+		Return(cc.ZeebeClientCreatedResponse{Name: "orders-worker", ClientID: "id", ClientSecret: "secret"}, nil)
+
+	created, err := createClusterWithWorker(context.Background(), api, "orders")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "secret", created.ClientSecret)
+}
@@ -0,0 +1,87 @@
+package client_test
+
+import (
+	"flag"
+	"testing"
+	"time"
+
+	"github.com/camunda-community-hub/camunda-cloud-go-client/pkg/cc/cctest"
+	cc "github.com/camunda-community-hub/camunda-cloud-go-client/pkg/cc/client"
+	"github.com/camunda-community-hub/camunda-cloud-go-client/pkg/cc/recorder"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// record re-records the cassettes against cctest.
+var record = flag.Bool("record", false, "re-record the cassettes in testdata/cassettes")
+
+// Test_Cassette_readAPI_cctest replays the read calls recorded against cctest with strict decoding.
+// As cctest builds its payloads from types.go, this checks the recorder and the decoding of the
+// recorded payloads, not the shapes of the real API: see cc-ctl doctor --schema for those.
+func Test_Cassette_readAPI_cctest(t *testing.T) {
+	checkReadAPI(t, cassetteClient(t, "testdata/cassettes/read_api_cctest.yaml"))
+}
+
+func checkReadAPI(t *testing.T, ccClient *cc.CCClient) {
+	ccClient.SetSchemaDriftHandler(func(drift cc.SchemaDrift) {
+		t.Errorf("schema drift in %s", drift)
+	})
+
+	_, err := ccClient.Login("cctest", "cctest")
+	require.NoError(t, err)
+	params, err := ccClient.GetClusterParams()
+	require.NoError(t, err)
+	clusters, err := ccClient.GetClusters()
+	require.NoError(t, err)
+	require.NotEmpty(t, clusters)
+	status, err := ccClient.GetClusterDetails(clusters[0].ID)
+	require.NoError(t, err)
+	clients, err := ccClient.GetZeebeClients(clusters[0].ID)
+	require.NoError(t, err)
+	require.NotEmpty(t, clients)
+	details, err := ccClient.GetZeebeClientDetails(clusters[0].ID, clients[0].ClientID)
+	require.NoError(t, err)
+
+	assert.NotEmpty(t, params.Channels)
+	assert.NotEmpty(t, params.ClusterPlanTypes)
+	assert.NotEmpty(t, params.Regions)
+	assert.NotEmpty(t, clusters[0].Name)
+	assert.False(t, clusters[0].Created.IsZero())
+	assert.True(t, status.Ready.IsKnown(), "unknown health %q", status.Ready)
+	assert.Equal(t, clusters[0].ID, ccClient.ClusterStatusResponse.ClusterId)
+	assert.NotEmpty(t, details.ZEEBEADDRESS)
+}
+
+// cassetteClient returns a client replaying the cassette, or recording it against cctest with -record.
+func cassetteClient(t *testing.T, path string) *cc.CCClient {
+	ccClient := &cc.CCClient{}
+	ccClient.SetCCApiURL("cloud.camunda.io")
+
+	if !*record {
+		rec, err := recorder.New(path, recorder.ModeReplay, nil)
+		require.NoError(t, err)
+		ccClient.SetHTTPClient(rec.Client())
+		return ccClient
+	}
+
+	srv := cctest.NewServer()
+	t.Cleanup(srv.Close)
+	srv.Now = func() time.Time { return time.Date(2021, 3, 5, 10, 0, 0, 0, time.UTC) }
+	params := cctest.DefaultParams()
+	cluster := srv.AddCluster(cc.Cluster{
+		Name:             "recorded",
+		Channel:          params.Channels[0],
+		Generation:       params.Channels[0].DefaultGeneration,
+		ClusterPlantType: params.ClusterPlanTypes[0],
+		K8sContext:       cc.K8sContext{UUID: params.Regions[0].Id, Name: params.Regions[0].Name},
+	})
+	_, err := srv.AddZeebeClient(cluster.ID, "worker")
+	require.NoError(t, err)
+	srv.Configure(ccClient)
+
+	rec, err := recorder.New(path, recorder.ModeRecord, nil)
+	require.NoError(t, err)
+	ccClient.SetHTTPClient(rec.Client())
+	t.Cleanup(func() { require.NoError(t, rec.Stop()) })
+	return ccClient
+}
@@ -13,12 +13,15 @@ import (
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/semconv"
 	"go.opentelemetry.io/otel/trace"
-	"io/ioutil"
 	"log"
 	"net/http"
 	"strings"
+	"sync"
 )
 
+// defaultStatusConcurrency bounds the parallel status requests of GetClustersWithStatus.
+const defaultStatusConcurrency = 4
+
 type CCClient struct {
 	AuthResponsePayload AuthResponsePayload
 
@@ -37,6 +40,22 @@ type CCClient struct {
 	tracerURL string
 
 	ccApiURL string
+
+	apiURLOverride string
+
+	loginURLOverride string
+
+	statusConcurrency int
+
+	httpClient *http.Client
+
+	schemaDriftHandler func(SchemaDrift)
+
+	maxResponseBytes int64
+
+	interceptors []Interceptor
+
+	mu sync.Mutex
 }
 
 func (c *CCClient) TracingEnabled(tracingEnabled bool ){
@@ -51,6 +70,82 @@ func (c *CCClient) SetTracerURL(tracerURL string ){
 	c.tracerURL = tracerURL
 }
 
+// SetAPIURL replaces the management API base URL (https://api.<cc api url> by default),
+// for example to target a local stand-in of Camunda Cloud over plain HTTP.
+func (c *CCClient) SetAPIURL(apiURL string) {
+	c.apiURLOverride = strings.TrimSuffix(apiURL, "/")
+}
+
+// SetLoginURL replaces the OAuth server base URL (https://login.<cc api url> by default).
+func (c *CCClient) SetLoginURL(loginURL string) {
+	c.loginURLOverride = strings.TrimSuffix(loginURL, "/")
+}
+
+// APIURL returns the management API base URL the client talks to.
+func (c *CCClient) APIURL() string {
+	return c.apiURL()
+}
+
+// LoginURL returns the OAuth server base URL the client logs in with.
+func (c *CCClient) LoginURL() string {
+	return c.loginURL()
+}
+
+func (c *CCClient) apiURL() string {
+	if c.apiURLOverride != "" {
+		return c.apiURLOverride
+	}
+	return "https://api." + c.ccApiURL
+}
+
+func (c *CCClient) loginURL() string {
+	if c.loginURLOverride != "" {
+		return c.loginURLOverride
+	}
+	return "https://login." + c.ccApiURL
+}
+
+// SetHTTPClient replaces the HTTP client used for every request, for example to
+// record and replay the traffic in tests or to set timeouts and proxies.
+func (c *CCClient) SetHTTPClient(httpClient *http.Client) {
+	c.httpClient = httpClient
+}
+
+// HTTPClient returns the HTTP client used for every request.
+func (c *CCClient) HTTPClient() *http.Client {
+	return c.getHTTPClient()
+}
+
+func (c *CCClient) getHTTPClient() *http.Client {
+	if c.httpClient != nil {
+		return c.httpClient
+	}
+	return &http.Client{}
+}
+
+// CopySettings returns a new client, not logged in, with the HTTP client, interceptors, tracing,
+// concurrency, schema drift handler and response size limit of c, for example to talk to another
+// organization the same way. The URLs are not copied.
+func (c *CCClient) CopySettings() *CCClient {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return &CCClient{
+		tracer:             c.tracer,
+		tracingEnabled:     c.tracingEnabled,
+		tracerURL:          c.tracerURL,
+		statusConcurrency:  c.statusConcurrency,
+		httpClient:         c.httpClient,
+		schemaDriftHandler: c.schemaDriftHandler,
+		maxResponseBytes:   c.maxResponseBytes,
+		interceptors:       append([]Interceptor{}, c.interceptors...),
+	}
+}
+
+// SetStatusConcurrency sets how many cluster details GetClustersWithStatus fetches in parallel.
+func (c *CCClient) SetStatusConcurrency(statusConcurrency int) {
+	c.statusConcurrency = statusConcurrency
+}
+
 func (c *CCClient) InitTracer() func() {
 
 	// Create and install Jaeger export pipeline.
@@ -116,9 +211,12 @@ func (c *CCClient) getDevelopmentClusterPlan() ClusterPlantType {
 	return developmentClusterPlanType
 }
 
-func (c *CCClient) getDefaultRegion() Region {
+func (c *CCClient) getDefaultRegion() (Region, error) {
+	if len(c.ClusterParams.Regions) == 0 {
+		return Region{}, NewError("No region available, the cluster params may not be loaded")
+	}
 	//chose the first one as default
-	return c.ClusterParams.Regions[0]
+	return c.ClusterParams.Regions[0], nil
 }
 
 func (c *CCClient) GetClusterParams() (*ClusterParams, error) {
@@ -132,113 +230,156 @@ func (c *CCClient) GetClusterParamsWithContext(ctx context.Context) (*ClusterPar
 		defer span.End()
 	}
 
-	req, _ := http.NewRequest("GET", "https://api."+c.ccApiURL+"/clusters/parameters", nil)
-	req.Header.Set("Authorization", "Bearer "+c.AuthResponsePayload.AccessToken)
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	err := c.apiRequest(ctx, RequestInfo{Operation: "getClusterParams"}, "GET", "/clusters/parameters", nil, &c.ClusterParams)
 
-	if err != nil {
-		log.Printf("failed to create client cluster params, %v", err)
-		return &c.ClusterParams, err
-	}
-
-	body, _ := ioutil.ReadAll(resp.Body)
-	fmt.Println("response Body Get cluster params :", string(body))
-	err2 := json.Unmarshal(body, &c.ClusterParams)
-
-	if err2 != nil {
-		log.Printf("failed to parse body cluster params, %v, %s", err2, string(body))
-		return &c.ClusterParams, err2
-	}
-
-	return &c.ClusterParams, nil
+	return &c.ClusterParams, err
 }
+
 func (c *CCClient) GetClusterDetails(clusterId string) (ClusterStatus, error) {
 	ctx := context.Background()
 	return c.GetClusterDetailsWithContext(ctx, clusterId)
 }
 
+// GetClusterDetailsWithContext returns the status of a cluster, HealthNotFound when it does not exist.
 func (c *CCClient) GetClusterDetailsWithContext(ctx context.Context, clusterId string) (ClusterStatus, error) {
 	if c.tracingEnabled {
 		_, span := c.tracer.Start(ctx, "getClusterDetails")
 		defer span.End()
 	}
-	req, _ := http.NewRequest("GET", "https://api."+c.ccApiURL+"/clusters/"+clusterId, nil)
-	req.Header.Set("Authorization", "Bearer "+c.AuthResponsePayload.AccessToken)
 
-	var clusterStatus = ClusterStatus{}
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	// The details are a whole cluster, of which only the id and status are kept.
+	var cluster = Cluster{}
+	err := c.apiRequest(ctx, RequestInfo{Operation: "getClusterDetails", ClusterID: clusterId}, "GET", "/clusters/"+clusterId, nil, &cluster)
 
+	httpErr := &HTTPError{}
+	if errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusNotFound {
+		return ClusterStatus{Ready: HealthNotFound}, nil
+	}
 	if err != nil {
-		log.Printf("failed to create client cluster details, %v", err)
-		return clusterStatus, err
+		return ClusterStatus{}, err
 	}
 
-	//fmt.Println("response Status cluster params:", resp.Status)
-	//fmt.Println("response Headers cluster params:", resp.Header)
-	body, _ := ioutil.ReadAll(resp.Body)
-	//fmt.Println("response Body cluster params :", string(body))
-	err2 := json.Unmarshal(body, &c.ClusterStatusResponse)
-	if err2 != nil {
-		//log.Printf("failed to parse body cluster details, %v,  %s", err2, string(body))
-		clusterStatus.Ready = "Not Found"
-		return clusterStatus, nil
-	}
-	clusterStatus = c.ClusterStatusResponse.ClusterStatus
-	return clusterStatus, nil
+	var clusterStatusResponse = ClusterStatusResponse{ClusterId: cluster.ID, ClusterStatus: cluster.Status}
+	c.mu.Lock()
+	c.ClusterStatusResponse = clusterStatusResponse
+	c.mu.Unlock()
+	return clusterStatusResponse.ClusterStatus, nil
+}
 
+func (c *CCClient) GetCluster(clusterID string) (Cluster, error) {
+	ctx := context.Background()
+	return c.GetClusterWithContext(ctx, clusterID)
 }
 
+// GetClusterWithContext returns the whole cluster, with its configuration and IP allowlist.
+func (c *CCClient) GetClusterWithContext(ctx context.Context, clusterID string) (Cluster, error) {
 
-func (c *CCClient) CreateClusterCustomConfig(clusterParams ClusterCreationParams) (string, error) {
+	if c.tracingEnabled {
+		_, span := c.tracer.Start(ctx, "getCluster")
+		defer span.End()
+	}
+
+	cluster := Cluster{}
+
+	if len(clusterID) == 0 {
+		return cluster, NewError("Cluster id should not be empty")
+	}
+
+	err := c.apiRequest(ctx, RequestInfo{Operation: "getCluster", ClusterID: clusterID}, "GET", "/clusters/"+clusterID, nil, &cluster)
+	cluster.fillLinksFromStatus()
+
+	return cluster, err
+}
+
+func (c *CCClient) SetIPAllowlist(clusterID string, entries []IPAllowlistEntry) (bool, error) {
 	ctx := context.Background()
-	return c.CreateClusterCustomConfigWithContext(ctx, clusterParams)
+	return c.SetIPAllowlistWithContext(ctx, clusterID, entries)
 }
 
-func (c *CCClient) CreateClusterCustomConfigWithContext(ctx context.Context, clusterParams ClusterCreationParams) (string, error) {
+// SetIPAllowlistWithContext replaces the IP allowlist of a cluster, an empty list allows any IP.
+func (c *CCClient) SetIPAllowlistWithContext(ctx context.Context, clusterID string, entries []IPAllowlistEntry) (bool, error) {
 
 	if c.tracingEnabled {
-		_, span := c.tracer.Start(ctx, "createClusterCustomConfig")
+		_, span := c.tracer.Start(ctx, "setIPAllowlist")
 		defer span.End()
 	}
 
-	_, existsErr := c.clusterExistsValidator(clusterParams.ClusterName)
+	if len(clusterID) == 0 {
+		return false, NewError("Cluster id should not be empty")
+	}
 
-	if existsErr != nil {
-		return "", existsErr
+	if entries == nil {
+		entries = []IPAllowlistEntry{}
 	}
 
-	jsonStr, _ := json.Marshal(clusterParams)
+	err := c.apiRequest(ctx, RequestInfo{Operation: "setIPAllowlist", ClusterID: clusterID}, "PUT", "/clusters/"+clusterID+"/ipwhitelist", IPAllowlistPayload{IPAllowlist: entries}, nil)
 
-	req, _ := http.NewRequest("POST", "https://api."+c.ccApiURL+"/clusters", bytes.NewBuffer(jsonStr))
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+c.AuthResponsePayload.AccessToken)
+	return err == nil, err
+}
 
-	fmt.Println("Request create cluster :", req)
+func (c *CCClient) UpgradeCluster(clusterID string, generationID string) (bool, error) {
+	ctx := context.Background()
+	return c.UpgradeClusterWithContext(ctx, clusterID, generationID)
+}
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+// UpgradeClusterWithContext moves a cluster to another generation of its channel. The cluster
+// reports Updating until the upgrade is over, see RolloutGeneration to wait for it.
+func (c *CCClient) UpgradeClusterWithContext(ctx context.Context, clusterID string, generationID string) (bool, error) {
 
-	defer resp.Body.Close()
+	if c.tracingEnabled {
+		_, span := c.tracer.Start(ctx, "upgradeCluster")
+		defer span.End()
+	}
 
-	body, _ := ioutil.ReadAll(resp.Body)
+	if len(clusterID) == 0 {
+		return false, NewError("Cluster id should not be empty")
+	}
 
-	if err != nil {
-		log.Printf("failed to create client, %v", err)
+	if len(generationID) == 0 {
+		return false, NewError("Generation id should not be empty")
+	}
+
+	err := c.apiRequest(ctx, RequestInfo{Operation: "upgradeCluster", ClusterID: clusterID}, "PUT", "/clusters/"+clusterID+"/generation", UpgradeClusterPayload{GenerationID: generationID}, nil)
+
+	return err == nil, err
+}
+
+func (c *CCClient) CreateClusterCustomConfig(clusterParams ClusterCreationParams) (string, error) {
+	ctx := context.Background()
+	return c.CreateClusterCustomConfigWithContext(ctx, clusterParams)
+}
+
+func (c *CCClient) CreateClusterCustomConfigWithContext(ctx context.Context, clusterParams ClusterCreationParams) (string, error) {
+
+	if c.tracingEnabled {
+		_, span := c.tracer.Start(ctx, "createClusterCustomConfig")
+		defer span.End()
+	}
+
+	if err := c.checkScope("createCluster"); err != nil {
 		return "", err
 	}
 
-	err2 := json.Unmarshal(body, &c.ClusterCreatedResponse)
+	_, existsErr := c.clusterExistsValidatorWithContext(ctx, clusterParams.ClusterName)
+
+	if existsErr != nil {
+		return "", existsErr
+	}
+
+	return c.createCluster(ctx, clusterParams)
+}
 
-	if err2 != nil {
-		log.Printf("Body to unmarshal: %s", string(body))
-		log.Printf("failed to parse body for create cluster, %v", err2)
-		return "", err2
+// createCluster posts the creation of a cluster and returns its id.
+func (c *CCClient) createCluster(ctx context.Context, clusterParams ClusterCreationParams) (string, error) {
+	created := ClusterCreatedResponse{}
+	if err := c.apiRequest(ctx, RequestInfo{Operation: "createCluster"}, "POST", "/clusters", clusterParams, &created); err != nil {
+		return "", err
 	}
 
-	return c.ClusterCreatedResponse.ClusterId, nil
+	c.mu.Lock()
+	c.ClusterCreatedResponse = created
+	c.mu.Unlock()
+	return created.ClusterId, nil
 }
 
 func (c *CCClient) CreateClusterWithParams(clusterName string, clusterPlanName string, channelName string, generationName string, clusterRegion string) (string, error) {
@@ -251,7 +392,11 @@ func (c *CCClient) CreateClusterWithParamsAndContext(ctx context.Context, cluste
 		_, span := c.tracer.Start(ctx, "createClusterWithParams")
 		defer span.End()
 	}
-	_, existsErr := c.clusterExistsValidator(clusterName)
+
+	if err := c.checkScope("createCluster"); err != nil {
+		return "", err
+	}
+	_, existsErr := c.clusterExistsValidatorWithContext(ctx, clusterName)
 
 	if existsErr != nil {
 		return "", existsErr
@@ -263,9 +408,15 @@ func (c *CCClient) CreateClusterWithParamsAndContext(ctx context.Context, cluste
 	var generation = Generation{}
 
 	if clusterRegion != "" {
-		region, _ = c.getClusterRegionByName(clusterRegion)
+		var err error
+		if region, err = c.getClusterRegionByName(clusterRegion); err != nil {
+			return "", err
+		}
 	} else {
-		region = c.getDefaultRegion()
+		var err error
+		if region, err = c.getDefaultRegion(); err != nil {
+			return "", err
+		}
 	}
 
 	if channelName != "" {
@@ -275,7 +426,7 @@ func (c *CCClient) CreateClusterWithParamsAndContext(ctx context.Context, cluste
 	}
 
 	if generationName != "" {
-		generation = c.getGenerationByNameForSelectedChannel(channel, clusterPlanName)
+		generation = c.getGenerationByNameForSelectedChannel(channel, generationName)
 	} else {
 		generation = channel.DefaultGeneration
 	}
@@ -286,40 +437,11 @@ func (c *CCClient) CreateClusterWithParamsAndContext(ctx context.Context, cluste
 		clusterPlan = c.getDevelopmentClusterPlan()
 	}
 
-	var jsonStr, _ = json.Marshal(NewClusterCreationParams(clusterName,
+	return c.createCluster(ctx, NewClusterCreationParams(clusterName,
 		channel.Id,
 		generation.Id,
 		region.Id,
 		clusterPlan.Id))
-
-	req, err := http.NewRequest("POST", "https://api."+c.ccApiURL+"/clusters/", bytes.NewBuffer(jsonStr))
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+c.AuthResponsePayload.AccessToken)
-
-	fmt.Println("Request create cluster :", req)
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
-
-	defer resp.Body.Close()
-	fmt.Println("\n\n\nCreate Cluster Response Status:", resp.Status)
-	fmt.Println("response Headers:", resp.Header)
-	body, _ := ioutil.ReadAll(resp.Body)
-
-	if err != nil {
-		log.Printf("failed to create client, %v", err)
-		return "", err
-	}
-
-	err2 := json.Unmarshal(body, &c.ClusterCreatedResponse)
-
-	if err2 != nil {
-		log.Printf("Body to unmarshal: %s ", string(body))
-		log.Printf("failed to parse body for create cluster, %v", err2)
-		return "", err2
-	}
-
-	return c.ClusterCreatedResponse.ClusterId, nil
 }
 
 func (c *CCClient) getGenerationByNameForSelectedChannel(channel Channel, generationName string) Generation {
@@ -357,7 +479,11 @@ func (c *CCClient) CreateClusterDefaultWithContext(ctx context.Context, clusterN
 		_, span := c.tracer.Start(ctx, "createClusterDefault")
 		defer span.End()
 	}
-	_, existsErr := c.clusterExistsValidator(clusterName)
+
+	if err := c.checkScope("createCluster"); err != nil {
+		return "", err
+	}
+	_, existsErr := c.clusterExistsValidatorWithContext(ctx, clusterName)
 
 	if existsErr != nil {
 		return "", existsErr
@@ -365,41 +491,15 @@ func (c *CCClient) CreateClusterDefaultWithContext(ctx context.Context, clusterN
 
 	var channel = c.getDefaultStableClusterChannel()
 	var clusterPlan = c.getDevelopmentClusterPlan()
-	var region = c.getDefaultRegion()
-	var jsonStr, _ = json.Marshal(NewClusterCreationParams(clusterName,
+	region, err := c.getDefaultRegion()
+	if err != nil {
+		return "", err
+	}
+	return c.createCluster(ctx, NewClusterCreationParams(clusterName,
 		channel.Id,
 		channel.DefaultGeneration.Id,
 		region.Id,
 		clusterPlan.Id))
-
-	req, err := http.NewRequest("POST", "https://api."+c.ccApiURL+"/clusters/", bytes.NewBuffer(jsonStr))
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+c.AuthResponsePayload.AccessToken)
-
-	fmt.Println("Request create cluster :", req)
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
-
-	defer resp.Body.Close()
-	fmt.Println("\n\n\nCreate Cluster Response Status:", resp.Status)
-	fmt.Println("response Headers:", resp.Header)
-	body, _ := ioutil.ReadAll(resp.Body)
-
-	if err != nil {
-		log.Printf("failed to create client, %v", err)
-		return "", err
-	}
-
-	err2 := json.Unmarshal(body, &c.ClusterCreatedResponse)
-
-	if err2 != nil {
-		log.Printf("Body to unmarshal: %s ", string(body))
-		log.Printf("failed to parse body for create cluster, %v", err2)
-		return "", err2
-	}
-
-	return c.ClusterCreatedResponse.ClusterId, nil
 }
 
 func (c *CCClient) Login(clientId string, clientSecret string) (bool, error) {
@@ -414,33 +514,31 @@ func (c *CCClient) LoginWithContext(ctx context.Context, clientId string, client
 	}
 	jsonStr, _ := json.Marshal(NewAuthRequestPayload(clientId, clientSecret))
 
-	req, err := http.NewRequest("POST", "https://login."+c.ccApiURL+"/oauth/token", bytes.NewBuffer(jsonStr))
+	req, err := http.NewRequestWithContext(ctx, "POST", c.loginURL()+"/oauth/token", bytes.NewBuffer(jsonStr))
 	req.Header.Set("Content-Type", "application/json")
 
 	//fmt.Println("Request :", req)
-	client := &http.Client{}
-	resp, err := client.Do(req)
-
-	defer resp.Body.Close()
+	resp, err := c.send(RequestInfo{Operation: "login"}, req)
 
 	if err != nil {
 		log.Printf("failed to create client for login, %v", err)
 		return false, err
 	}
-	body, _ := ioutil.ReadAll(resp.Body)
+
+	defer resp.Body.Close()
 	//fmt.Println("response Status:", resp.Status)
-	//fmt.Println("response Body:", string(body))
 	if resp.StatusCode == 200 {
-		err2 := json.Unmarshal(body, &c.AuthResponsePayload)
+		err2 := c.decodeBody("login", resp.Body, &c.AuthResponsePayload)
 		//		log.Printf("json from login parsed!")
 		if err2 != nil {
-			log.Printf("failed to parse body for login, %v, %s", err2, string(body))
+			log.Printf("failed to parse body for login, %v", err2)
 			return false, err2
 		}
 		return true, nil
 	} else {
 		log.Printf("HTTP Error trying to login, %v", resp.StatusCode)
-		return false, errors.New(fmt.Sprintf("HTTP Error trying to login: %d", resp.StatusCode))
+		body, _ := c.readBody(resp.Body)
+		return false, &HTTPError{Operation: "login", StatusCode: resp.StatusCode, Body: string(body)}
 	}
 }
 
@@ -455,27 +553,11 @@ func (c *CCClient) DeleteClusterWithContext(ctx context.Context, clusterId strin
 		_, span := c.tracer.Start(ctx, "deleteCluster")
 		defer span.End()
 	}
-	req, _ := http.NewRequest("DELETE", "https://api."+c.ccApiURL+"/clusters/"+clusterId, nil)
-	req.Header.Set("Authorization", "Bearer "+c.AuthResponsePayload.AccessToken)
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
-
-	if err != nil {
-		log.Printf("failed to create client cluster params, %v", err)
-		return false, errors.New(fmt.Sprintf("HTTP Error trying to login: %d", resp.StatusCode))
-	}
 
-	if resp.StatusCode == 200 {
-		return true, nil
+	if err := c.apiRequest(ctx, RequestInfo{Operation: "deleteCluster", ClusterID: clusterId}, "DELETE", "/clusters/"+clusterId, nil, nil); err != nil {
+		return false, err
 	}
-	return false, errors.New(fmt.Sprintf("HTTP Error trying to login: %d", resp.StatusCode))
-	//fmt.Println("response Status delete cluster:", resp.Status)
-	//fmt.Println("response Headers delete cluster:", resp.Header)
-	//body, _ := ioutil.ReadAll(resp.Body)
-
-	//fmt.Println("response Body delete cluster :", string(body))
-
+	return true, nil
 }
 
 
@@ -491,38 +573,76 @@ func (c *CCClient) GetClustersWithContext(ctx context.Context) ( []Cluster, erro
 		_, span := c.tracer.Start(ctx, "getClusters")
 		defer span.End()
 	}
+
 	data := []Cluster{}
 
-	req, _ := http.NewRequest("GET", "https://api."+c.ccApiURL+"/clusters", nil)
+	err := c.apiRequest(ctx, RequestInfo{Operation: "getClusters"}, "GET", "/clusters", nil, &data)
 
-	req.Header.Set("Authorization", "Bearer "+c.AuthResponsePayload.AccessToken)
+	return data, err
+}
 
-	client := &http.Client{}
+// GetClustersWithStatus lists all clusters and fetches the details of the clusters
+// whose status is missing from the listing, using a bounded pool of workers.
+// The clusters keep the order of the listing. When some details cannot be fetched, their
+// status is left empty and a *StatusError with every failure is returned along with the clusters.
+func (c *CCClient) GetClustersWithStatus(ctx context.Context) ([]Cluster, error) {
 
-	resp, err := client.Do(req)
+	if c.tracingEnabled {
+		_, span := c.tracer.Start(ctx, "getClustersWithStatus")
+		defer span.End()
+	}
+
+	clusters, err := c.GetClustersWithContext(ctx)
 
 	if err != nil {
-		log.Printf("Failed to get all clusters")
-		return data, err
+		return clusters, err
 	}
 
-	body, _ := ioutil.ReadAll(resp.Body)
-	err2 := json.Unmarshal(body, &data)
+	workers := c.statusConcurrency
+	if workers <= 0 {
+		workers = defaultStatusConcurrency
+	}
+
+	failures := forEachIndex(ctx, len(clusters), workers, func(i int) error {
+		if clusters[i].Status.Ready != "" {
+			clusters[i].fillLinksFromStatus()
+			return nil
+		}
+		status, err := c.GetClusterDetailsWithContext(ctx, clusters[i].ID)
+		if err == nil && status.Ready == HealthNotFound {
+			err = fmt.Errorf("%w: %s", ErrClusterNotFound, clusters[i].ID)
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w", clusters[i].ID, err)
+		}
+		clusters[i].Status = status
+		clusters[i].fillLinksFromStatus()
+		return nil
+	})
 
-	if err2 != nil {
-		log.Printf("Failed to unmarshal response body ->  %s", string(body))
-		return data, err2
+	if ctx.Err() != nil {
+		return clusters, ctx.Err()
 	}
 
-	return data, nil
+	statusErr := &StatusError{}
+	for _, err := range failures {
+		if err != nil {
+			statusErr.Errors = append(statusErr.Errors, err)
+		}
+	}
+	if len(statusErr.Errors) > 0 {
+		return clusters, statusErr
+	}
+	return clusters, nil
 }
 
-
 func (c *CCClient) GetClusterByName(name string) (Cluster, error) {
 	ctx := context.Background()
 	return c.GetClusterByNameWithContext(ctx, name)
 }
 
+// GetClusterByNameWithContext returns the cluster with exactly this name, or an empty
+// cluster when there is none. ErrAmbiguousName is returned when several clusters share the name.
 func (c *CCClient) GetClusterByNameWithContext(ctx context.Context, name string) (Cluster, error) {
 
 	if c.tracingEnabled {
@@ -531,17 +651,18 @@ func (c *CCClient) GetClusterByNameWithContext(ctx context.Context, name string)
 	}
 	data := Cluster{}
 
-	clusters, err := c.GetClusters()
+	clusters, err := c.ListClusters(ctx, ListOptions{Name: name})
 
 	if err != nil {
 		return data, err
 	}
 
-	for _, cluster := range clusters {
+	if len(clusters) > 1 {
+		return data, fmt.Errorf("%w: %s", ErrAmbiguousName, name)
+	}
 
-		if cluster.Name == name {
-			return cluster, nil
-		}
+	if len(clusters) == 1 {
+		return clusters[0], nil
 	}
 
 	return data, nil
@@ -559,14 +680,18 @@ func (c *CCClient) clusterExistsValidatorWithContext(ctx context.Context, cluste
 		defer span.End()
 	}
 
-	cluster, err := c.GetClusterByName(clusterName)
+	cluster, err := c.GetClusterByNameWithContext(ctx, clusterName)
+
+	if errors.Is(err, ErrAmbiguousName) {
+		return "", ErrClusterExists
+	}
 
 	if err != nil {
 		return "", err
 	}
 
 	if cluster.ID != "" {
-		return "", errors.New("Cluster name already exists on Camunda Cloud")
+		return "", ErrClusterExists
 	}
 
 	return "", nil
@@ -590,29 +715,9 @@ func (c *CCClient) GetZeebeClientsWithContext(ctx context.Context, clusterID str
 		return data, NewError("Cluster id should not be empty")
 	}
 
-	req, _ := http.NewRequest("GET", "https://api."+c.ccApiURL+"/clusters/"+clusterID+"/clients", nil)
-
-	req.Header.Set("Authorization", "Bearer "+c.AuthResponsePayload.AccessToken)
-
-	client := &http.Client{}
-
-	resp, err := client.Do(req)
-
-	if err != nil {
-		log.Printf("Failed to get zeebe clients")
-		return data, err
-	}
-
-	body, _ := ioutil.ReadAll(resp.Body)
-
-	err2 := json.Unmarshal(body, &data)
-
-	if err2 != nil {
-		log.Printf("Failed to unmarshal response body ->  %s", string(body))
-		return data, err2
-	}
+	err := c.apiRequest(ctx, RequestInfo{Operation: "getZeebeClients", ClusterID: clusterID}, "GET", "/clusters/"+clusterID+"/clients", nil, &data)
 
-	return data, nil
+	return data, err
 }
 
 func (c *CCClient) GetZeebeClientDetails(clusterID string, clientID string) (ZeebeClientDetailsResponse, error) {
@@ -637,45 +742,32 @@ func (c *CCClient) GetZeebeClientDetailsWithContext(ctx context.Context, cluster
 		return data, NewError("Client id should not be empty")
 	}
 
-	req, _ := http.NewRequest("GET", "https://api."+c.ccApiURL+"/clusters/"+clusterID+"/clients/"+clientID, nil)
-
-	req.Header.Set("Authorization", "Bearer "+c.AuthResponsePayload.AccessToken)
-
-	client := &http.Client{}
-
-	resp, err := client.Do(req)
-
-	if err != nil {
-		log.Printf("Failed to get zeebe details")
-		return data, err
-	}
-
-	body, _ := ioutil.ReadAll(resp.Body)
+	err := c.apiRequest(ctx, RequestInfo{Operation: "getZeebeClientDetails", ClusterID: clusterID, ClientID: clientID}, "GET", "/clusters/"+clusterID+"/clients/"+clientID, nil, &data)
 
-	err2 := json.Unmarshal(body, &data)
-
-	if err2 != nil {
-		log.Printf("Failed to unmarshal response body ->  %s", string(body))
-		return data, err2
-	}
-
-	return data, nil
+	return data, err
 }
 
-func (c *CCClient) CreateZeebeClient(clusterID string, clientName string) (ZeebeClientCreatedResponse, error) {
+func (c *CCClient) CreateZeebeClient(clusterID string, clientName string, scopes ...ZeebeClientScope) (ZeebeClientCreatedResponse, error) {
 	ctx := context.Background()
-	return c.CreateZeebeClientWithContext(ctx, clusterID, clientName)
+	return c.CreateZeebeClientWithContext(ctx, clusterID, clientName, scopes...)
 }
 
-func (c *CCClient) CreateZeebeClientWithContext(ctx context.Context, clusterID string, clientName string) (ZeebeClientCreatedResponse, error) {
+// CreateZeebeClientWithContext creates a Zeebe client restricted to the given scopes.
+// Without scopes the client gets the default scopes of Camunda Cloud.
+func (c *CCClient) CreateZeebeClientWithContext(ctx context.Context, clusterID string, clientName string, scopes ...ZeebeClientScope) (ZeebeClientCreatedResponse, error) {
 
 	if c.tracingEnabled {
 		_, span := c.tracer.Start(ctx, "createZeebeClient")
 		defer span.End()
 	}
 
+	if err := c.checkScope("createZeebeClient"); err != nil {
+		return ZeebeClientCreatedResponse{}, err
+	}
+
 	zeebeClient := ZeebeClientCreatePayload{
-		ClientName: clientName,
+		ClientName:  clientName,
+		Permissions: scopes,
 	}
 
 	if len(clusterID) == 0 {
@@ -686,33 +778,47 @@ func (c *CCClient) CreateZeebeClientWithContext(ctx context.Context, clusterID s
 		return ZeebeClientCreatedResponse{}, NewError("Client name should not be empty")
 	}
 
-	jsonStr, _ := json.Marshal(zeebeClient)
+	created := ZeebeClientCreatedResponse{}
+	if err := c.apiRequest(ctx, RequestInfo{Operation: "createZeebeClient", ClusterID: clusterID}, "POST", "/clusters/"+clusterID+"/clients", zeebeClient, &created); err != nil {
+		return ZeebeClientCreatedResponse{}, err
+	}
 
-	req, _ := http.NewRequest("POST", "https://api."+c.ccApiURL+"/clusters/"+clusterID+"/clients", bytes.NewBuffer(jsonStr))
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+c.AuthResponsePayload.AccessToken)
+	c.mu.Lock()
+	c.ZeebeClientCreate = created
+	c.mu.Unlock()
+	return created, nil
+}
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+func (c *CCClient) UpdateZeebeClient(clusterID string, clientID string, scopes ...ZeebeClientScope) (bool, error) {
+	ctx := context.Background()
+	return c.UpdateZeebeClientWithContext(ctx, clusterID, clientID, scopes...)
+}
 
-	defer resp.Body.Close()
+// UpdateZeebeClientWithContext replaces the scopes of an existing Zeebe client.
+func (c *CCClient) UpdateZeebeClientWithContext(ctx context.Context, clusterID string, clientID string, scopes ...ZeebeClientScope) (bool, error) {
 
-	body, _ := ioutil.ReadAll(resp.Body)
+	if c.tracingEnabled {
+		_, span := c.tracer.Start(ctx, "updateZeebeClient")
+		defer span.End()
+	}
 
-	if err != nil {
-		log.Printf("failed to create zeebe client, %v", err)
-		return ZeebeClientCreatedResponse{}, err
+	if len(clusterID) == 0 {
+		return false, NewError("Cluster id should not be empty")
 	}
 
-	err2 := json.Unmarshal(body, &c.ZeebeClientCreate)
+	if len(clientID) == 0 {
+		return false, NewError("Client id should not be empty")
+	}
 
-	if err2 != nil {
-		log.Printf("Body to unmarshal: %s", string(body))
-		log.Printf("failed to parse body for zeebe client, %v", err2)
-		return ZeebeClientCreatedResponse{}, err2
+	if len(scopes) == 0 {
+		return false, NewError("At least one scope should be provided")
 	}
 
-	return c.ZeebeClientCreate, nil
+	info := RequestInfo{Operation: "updateZeebeClient", ClusterID: clusterID, ClientID: clientID}
+	if err := c.apiRequest(ctx, info, "PUT", "/clusters/"+clusterID+"/clients/"+clientID, ZeebeClientUpdatePayload{Permissions: scopes}, nil); err != nil {
+		return false, err
+	}
+	return true, nil
 }
 
 func (c *CCClient) DeleteZeebeClient(clusterID string, clientID string) (bool, error) {
@@ -731,20 +837,13 @@ func (c *CCClient) DeleteZeebeClientWithContext(ctx context.Context, clusterID s
 		return false, NewError("Cluster id should not be empty")
 	}
 
-	req, _ := http.NewRequest("DELETE", "https://api."+c.ccApiURL+"/clusters/"+clusterID+"/clients/"+clientID, nil)
-	req.Header.Set("Authorization", "Bearer "+c.AuthResponsePayload.AccessToken)
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
-
-	if err != nil {
-		log.Printf("Failed to delete zeebe client, %v", err)
-		return false, errors.New(fmt.Sprintf("HTTP Error trying to delete zeebe client: %d", resp.StatusCode))
+	if len(clientID) == 0 {
+		return false, NewError("Client id should not be empty")
 	}
 
-	if resp.StatusCode == 200 {
-		return true, nil
+	info := RequestInfo{Operation: "deleteZeebeClient", ClusterID: clusterID, ClientID: clientID}
+	if err := c.apiRequest(ctx, info, "DELETE", "/clusters/"+clusterID+"/clients/"+clientID, nil, nil); err != nil {
+		return false, err
 	}
-	return false, errors.New(fmt.Sprintf("HTTP Error trying to delete zeebe client: %d", resp.StatusCode))
-
+	return true, nil
 }
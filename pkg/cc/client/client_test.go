@@ -1,14 +1,457 @@
-package client
+package client_test
 
 import (
-	"github.com/stretchr/testify/assert"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
 	"testing"
+	"time"
+
+	"github.com/camunda-community-hub/camunda-cloud-go-client/pkg/cc/cctest"
+	cc "github.com/camunda-community-hub/camunda-cloud-go-client/pkg/cc/client"
+	"github.com/stretchr/testify/assert"
 )
 
 func Test_getClusterParams(t *testing.T) {
+	srv := cctest.NewServer()
+	defer srv.Close()
+
+	params, err := srv.Client().GetClusterParams()
+
+	assert.NoError(t, err)
+	assert.Equal(t, cctest.DefaultParams(), *params)
+}
+
+func Test_Login(t *testing.T) {
+	srv := cctest.NewServer()
+	defer srv.Close()
+	srv.ClientID, srv.ClientSecret = "id", "secret"
+
+	ccClient := cc.CCClient{}
+	srv.Configure(&ccClient)
+
+	ok, err := ccClient.Login("id", "wrong")
+	assert.False(t, ok)
+	assert.EqualError(t, err, "HTTP Error trying to login: 401")
+
+	ok, err = ccClient.Login("id", "secret")
+	assert.True(t, ok)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, ccClient.AuthResponsePayload.AccessToken)
+}
+
+func Test_ClusterLifecycle(t *testing.T) {
+	srv := cctest.NewServer()
+	defer srv.Close()
+	ccClient := srv.Client()
+
+	clusterID, err := ccClient.CreateClusterDefault("my-cluster")
+	assert.NoError(t, err)
+
+	status, err := ccClient.GetClusterDetails(clusterID)
+	assert.NoError(t, err)
+	assert.Equal(t, cc.HealthCreating, status.Ready)
+
+	status, _ = ccClient.GetClusterDetails(clusterID)
+	assert.True(t, status.IsReady())
+
+	cluster, err := ccClient.GetClusterByName("my-cluster")
+	assert.NoError(t, err)
+	assert.Equal(t, clusterID, cluster.ID)
+	assert.Equal(t, "Stable", cluster.Channel.Name)
+	assert.Equal(t, "Development", cluster.ClusterPlantType.Name)
+	assert.Equal(t, "Europe West", cluster.K8sContext.Name)
+
+	_, err = ccClient.CreateClusterDefault("my-cluster")
+	assert.EqualError(t, err, "Cluster name already exists on Camunda Cloud")
+
+	deleted, err := ccClient.DeleteCluster(clusterID)
+	assert.True(t, deleted)
+	assert.NoError(t, err)
+
+	status, _ = ccClient.GetClusterDetails(clusterID)
+	assert.Equal(t, cc.HealthDeleting, status.Ready)
+
+	status, _ = ccClient.GetClusterDetails(clusterID)
+	assert.Equal(t, cc.HealthNotFound, status.Ready)
+
+	created := srv.RequestsTo("POST /clusters")
+	assert.Len(t, created, 1)
+	payload := cc.ClusterCreationParams{}
+	assert.NoError(t, json.Unmarshal(created[0].Body, &payload))
+	assert.Equal(t, cc.NewClusterCreationParams("my-cluster", "channel-stable", "generation-stable-2", "region-europe-west1", "plan-development"), payload)
+}
+
+func Test_GetClusterByName_ambiguous(t *testing.T) {
+	srv := cctest.NewServer()
+	defer srv.Close()
+	srv.AddCluster(cc.Cluster{Name: "twin"})
+	srv.AddCluster(cc.Cluster{Name: "twin"})
+
+	_, err := srv.Client().GetClusterByName("twin")
 
+	assert.True(t, errors.Is(err, cc.ErrAmbiguousName))
+}
+
+func Test_GetClustersWithStatus(t *testing.T) {
+	srv := cctest.NewServer()
+	defer srv.Close()
+	ccClient := srv.Client()
+	ccClient.SetStatusConcurrency(2)
+
+	srv.AddCluster(cc.Cluster{Name: "listed-with-status"})
+	for _, name := range []string{"a", "b", "c"} {
+		_, err := ccClient.CreateClusterDefault(name)
+		assert.NoError(t, err)
+	}
+	srv.ResetRequests()
+
+	clusters, err := ccClient.GetClustersWithStatus(context.Background())
+
+	assert.NoError(t, err)
+	assert.Len(t, clusters, 4)
+	for _, cluster := range clusters {
+		assert.NotEmpty(t, cluster.Status.Ready, cluster.Name)
+		assert.NotEmpty(t, cluster.Links.Zeebe, cluster.Name)
+	}
+	assert.Len(t, srv.RequestsTo("GET /clusters/{clusterId}"), 0, "the listing already reports the status")
+
+	srv.OmitListingStatus = true
+	clusters, err = ccClient.GetClustersWithStatus(context.Background())
+
+	assert.NoError(t, err)
+	for _, cluster := range clusters {
+		assert.NotEmpty(t, cluster.Status.Ready, cluster.Name)
+		assert.NotEmpty(t, cluster.Links.Zeebe, cluster.Name)
+	}
+	assert.Len(t, srv.RequestsTo("GET /clusters/{clusterId}"), 4)
+}
+
+func Test_GetClusters_fullModel(t *testing.T) {
+	srv := cctest.NewServer()
+	defer srv.Close()
+	ccClient := srv.Client()
+	clusterID, err := ccClient.CreateClusterWithParams("orders", "Production - S", "Stable", "Zeebe 0.26.1", "US East")
+	assert.NoError(t, err)
+
+	clusters, err := ccClient.GetClusters()
+
+	assert.NoError(t, err)
+	if assert.Len(t, clusters, 1) {
+		cluster := clusters[0]
+		assert.Equal(t, clusterID, cluster.ID)
+		assert.Equal(t, cc.K8sContext{UUID: "region-us-east1", Name: "US East"}, cluster.K8sContext)
+		assert.Equal(t, "plan-production-s", cluster.ClusterPlantType.Id)
+		assert.Equal(t, "Production - S", cluster.ClusterPlantType.Name)
+		assert.Equal(t, "Zeebe 0.26.1", cluster.Generation.Name)
+		assert.Equal(t, cc.HealthCreating, cluster.Status.Ready)
+		assert.Contains(t, cluster.Status.ZeebeURL, clusterID)
+		assert.Contains(t, cluster.Links.Zeebe, clusterID)
+		assert.Contains(t, cluster.Links.Operate, clusterID)
+		assert.Contains(t, cluster.Links.Tasklist, clusterID)
+		assert.False(t, cluster.Created.IsZero())
+	}
+}
 
-	assert.Equal(t, true, true)
+func Test_GetClustersWithStatus_deletedMeanwhile(t *testing.T) {
+	srv := cctest.NewServer()
+	defer srv.Close()
+	srv.OmitListingStatus = true
+	ccClient := srv.Client()
+	cluster := srv.AddCluster(cc.Cluster{Name: "orders"})
+	srv.InjectFault("GET /clusters/{clusterId}", cctest.Fail(http.StatusNotFound, 1))
+
+	clusters, err := ccClient.GetClustersWithStatus(context.Background())
+
+	assert.True(t, errors.Is(err, cc.ErrClusterNotFound), "%v", err)
+	assert.Contains(t, err.Error(), cluster.ID)
+	if assert.Len(t, clusters, 1) {
+		assert.Empty(t, clusters[0].Status.Ready)
+		assert.False(t, clusters[0].Status.IsDegraded(), "no status is not a degraded one")
+	}
+}
+
+func Test_GetClustersWithStatus_pool(t *testing.T) {
+	srv := cctest.NewServer()
+	defer srv.Close()
+	srv.OmitListingStatus = true
+	ccClient := srv.Client()
+	ccClient.SetStatusConcurrency(2)
+	for _, name := range []string{"a", "b", "c", "d", "e", "f"} {
+		srv.AddCluster(cc.Cluster{Name: name})
+	}
+
+	var mu sync.Mutex
+	inFlight, maxInFlight, calls := 0, 0, 0
+	ccClient.Use(func(info cc.RequestInfo, req *http.Request, next cc.Next) (*http.Response, error) {
+		if info.Operation != "getClusterDetails" {
+			return next(req)
+		}
+		mu.Lock()
+		inFlight++
+		calls++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		// The first clusters answer last.
+		delay := time.Duration(7-calls) * 5 * time.Millisecond
+		mu.Unlock()
+		time.Sleep(delay)
+		defer func() {
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+		}()
+		return next(req)
+	})
+
+	clusters, err := ccClient.GetClustersWithStatus(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, maxInFlight)
+	assert.Len(t, srv.RequestsTo("GET /clusters/{clusterId}"), 6)
+	if assert.Len(t, clusters, 6) {
+		for i, name := range []string{"a", "b", "c", "d", "e", "f"} {
+			assert.Equal(t, name, clusters[i].Name)
+			assert.Contains(t, clusters[i].Status.ZeebeURL, clusters[i].ID, "status of %s", name)
+		}
+	}
+}
+
+func Test_GetClustersWithStatus_errors(t *testing.T) {
+	srv := cctest.NewServer()
+	defer srv.Close()
+	srv.OmitListingStatus = true
+	ccClient := srv.Client()
+	ccClient.SetStatusConcurrency(1)
+	a := srv.AddCluster(cc.Cluster{Name: "a"})
+	b := srv.AddCluster(cc.Cluster{Name: "b"})
+	c := srv.AddCluster(cc.Cluster{Name: "c"})
+	srv.InjectFault("GET /clusters/{clusterId}", cctest.Fail(http.StatusInternalServerError, 1))
+	srv.InjectFault("GET /clusters/{clusterId}", cctest.Fail(http.StatusForbidden, 1))
+
+	clusters, err := ccClient.GetClustersWithStatus(context.Background())
+
+	assert.EqualError(t, err, "failed to get the status of 2 clusters: "+
+		a.ID+": HTTP Error trying to getClusterDetails: 500; "+b.ID+": HTTP Error trying to getClusterDetails: 403")
+	statusErr := &cc.StatusError{}
+	assert.True(t, errors.As(err, &statusErr))
+	assert.Len(t, statusErr.Errors, 2)
+	httpErr := &cc.HTTPError{}
+	assert.True(t, errors.As(err, &httpErr))
+	assert.Equal(t, http.StatusInternalServerError, httpErr.StatusCode)
+	if assert.Len(t, clusters, 3) {
+		assert.Empty(t, clusters[0].Status.Ready, "not reported as not found")
+		assert.Empty(t, clusters[1].Status.Ready)
+		assert.Equal(t, c.ID, clusters[2].ID)
+		assert.Equal(t, cc.HealthHealthy, clusters[2].Status.Ready)
+	}
 }
 
+func Test_GetClustersWithStatus_canceled(t *testing.T) {
+	srv := cctest.NewServer()
+	defer srv.Close()
+	srv.OmitListingStatus = true
+	ccClient := srv.Client()
+	ccClient.SetStatusConcurrency(1)
+	for _, name := range []string{"a", "b", "c", "d"} {
+		srv.AddCluster(cc.Cluster{Name: name})
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ccClient.Use(func(info cc.RequestInfo, req *http.Request, next cc.Next) (*http.Response, error) {
+		if info.Operation == "getClusterDetails" {
+			cancel()
+		}
+		return next(req)
+	})
+
+	_, err := ccClient.GetClustersWithStatus(ctx)
 
+	assert.Equal(t, context.Canceled, err)
+	assert.Less(t, len(srv.RequestsTo("GET /clusters/{clusterId}")), 4, "no details fetched once canceled")
+}
+
+func Test_ZeebeClients(t *testing.T) {
+	srv := cctest.NewServer()
+	defer srv.Close()
+	ccClient := srv.Client()
+	cluster := srv.AddCluster(cc.Cluster{Name: "workers"})
+
+	created, err := ccClient.CreateZeebeClient(cluster.ID, "worker", cc.ZeebeClientScopeZeebe)
+	assert.NoError(t, err)
+	assert.Equal(t, "worker", created.Name)
+	assert.NotEmpty(t, created.ClientSecret)
+
+	payload := cc.ZeebeClientCreatePayload{}
+	assert.NoError(t, json.Unmarshal(srv.RequestsTo("POST /clusters/{clusterId}/clients")[0].Body, &payload))
+	assert.Equal(t, []cc.ZeebeClientScope{cc.ZeebeClientScopeZeebe}, payload.Permissions)
+
+	updated, err := ccClient.UpdateZeebeClient(cluster.ID, created.ClientID, cc.ZeebeClientScopeZeebe, cc.ZeebeClientScopeOperate)
+	assert.True(t, updated)
+	assert.NoError(t, err)
+
+	clients, err := ccClient.GetZeebeClients(cluster.ID)
+	assert.NoError(t, err)
+	assert.Len(t, clients, 1)
+	assert.Equal(t, []cc.ZeebeClientScope{cc.ZeebeClientScopeZeebe, cc.ZeebeClientScopeOperate}, clients[0].Permissions)
+
+	details, err := ccClient.GetZeebeClientDetails(cluster.ID, created.ClientID)
+	assert.NoError(t, err)
+	assert.Equal(t, cluster.Links.Zeebe, details.ZEEBEADDRESS)
+
+	deleted, err := ccClient.DeleteZeebeClient(cluster.ID, created.ClientID)
+	assert.True(t, deleted)
+	assert.NoError(t, err)
+	assert.Empty(t, srv.ZeebeClients(cluster.ID))
+}
+
+func Test_CreateCluster_rejected(t *testing.T) {
+	srv := cctest.NewServer()
+	defer srv.Close()
+	ccClient := srv.Client()
+	_, err := ccClient.GetClusterParams()
+	assert.NoError(t, err)
+
+	srv.InjectFault("POST /clusters", cctest.Fault{Status: 400, Body: `{"message":"quota exceeded"}`, Times: 1})
+	clusterID, err := ccClient.CreateClusterDefault("quota")
+	assert.Empty(t, clusterID)
+	httpErr := &cc.HTTPError{}
+	if assert.True(t, errors.As(err, &httpErr)) {
+		assert.Equal(t, 400, httpErr.StatusCode)
+		assert.Equal(t, `{"message":"quota exceeded"}`, httpErr.Body)
+	}
+
+	srv.InjectFault("POST /clusters", cctest.Fail(404, 1))
+	_, err = ccClient.CreateClusterWithParams("missing", "Development", "Stable", "", "Europe West")
+	assert.EqualError(t, err, "HTTP Error trying to createCluster: 404")
+
+	srv.InjectFault("POST /clusters", cctest.Fail(401, 1))
+	_, err = ccClient.CreateClusterCustomConfig(cc.NewClusterCreationParams("unauthorized", "channel-stable", "generation-stable-2", "region-europe-west1", "plan-development"))
+	assert.EqualError(t, err, "HTTP Error trying to createCluster: 401")
+}
+
+func Test_CreateClusterWithParams_unknownRegion(t *testing.T) {
+	srv := cctest.NewServer()
+	defer srv.Close()
+	ccClient := srv.Client()
+	_, err := ccClient.GetClusterParams()
+	assert.NoError(t, err)
+
+	_, err = ccClient.CreateClusterWithParams("nowhere", "", "", "", "Mars")
+
+	assert.EqualError(t, err, "No Region Found with name: Mars")
+	assert.Empty(t, srv.RequestsTo("POST /clusters"))
+}
+
+func Test_CreateCluster_paramsNotLoaded(t *testing.T) {
+	srv := cctest.NewServer()
+	defer srv.Close()
+	ccClient := cc.CCClient{}
+	srv.Configure(&ccClient)
+	_, err := ccClient.Login("cctest", "cctest")
+	assert.NoError(t, err)
+
+	_, err = ccClient.CreateClusterDefault("orders")
+	assert.EqualError(t, err, "No region available, the cluster params may not be loaded")
+
+	_, err = ccClient.CreateClusterWithParams("orders", "", "", "", "")
+	assert.EqualError(t, err, "No region available, the cluster params may not be loaded")
+	assert.Empty(t, srv.RequestsTo("POST /clusters"))
+}
+
+func Test_ZeebeClients_rejected(t *testing.T) {
+	srv := cctest.NewServer()
+	ccClient := srv.Client()
+	cluster := srv.AddCluster(cc.Cluster{Name: "workers"})
+	zeebeClient, _ := srv.AddZeebeClient(cluster.ID, "worker")
+
+	srv.InjectFault("POST /clusters/{clusterId}/clients", cctest.Fail(403, 1))
+	created, err := ccClient.CreateZeebeClient(cluster.ID, "starter")
+	assert.Equal(t, cc.ZeebeClientCreatedResponse{}, created)
+	assert.EqualError(t, err, "HTTP Error trying to createZeebeClient: 403")
+
+	srv.InjectFault("PUT /clusters/{clusterId}/clients/{clientId}", cctest.Fail(403, 1))
+	_, err = ccClient.UpdateZeebeClient(cluster.ID, zeebeClient.ClientID, cc.ZeebeClientScopeZeebe)
+	assert.EqualError(t, err, "HTTP Error trying to updateZeebeClient: 403")
+
+	srv.InjectFault("DELETE /clusters/{clusterId}/clients/{clientId}", cctest.Fail(500, 1))
+	_, err = ccClient.DeleteZeebeClient(cluster.ID, zeebeClient.ClientID)
+	assert.EqualError(t, err, "HTTP Error trying to deleteZeebeClient: 500")
+
+	srv.Close()
+	deleted, err := ccClient.DeleteZeebeClient(cluster.ID, zeebeClient.ClientID)
+	assert.False(t, deleted)
+	assert.Error(t, err, "a transport error is returned as is")
+}
+
+func Test_Deletes_noContent(t *testing.T) {
+	srv := cctest.NewServer()
+	defer srv.Close()
+	ccClient := srv.Client()
+	cluster := srv.AddCluster(cc.Cluster{Name: "workers"})
+	zeebeClient, _ := srv.AddZeebeClient(cluster.ID, "worker")
+	for _, route := range []string{"DELETE /clusters/{clusterId}/clients/{clientId}", "PUT /clusters/{clusterId}/clients/{clientId}", "DELETE /clusters/{clusterId}"} {
+		srv.InjectFault(route, cctest.Fail(http.StatusNoContent, 1))
+	}
+
+	deleted, err := ccClient.DeleteZeebeClient(cluster.ID, zeebeClient.ClientID)
+	assert.NoError(t, err)
+	assert.True(t, deleted)
+	updated, err := ccClient.UpdateZeebeClient(cluster.ID, zeebeClient.ClientID, cc.ZeebeClientScopeZeebe)
+	assert.NoError(t, err)
+	assert.True(t, updated)
+	deleted, err = ccClient.DeleteCluster(cluster.ID)
+	assert.NoError(t, err)
+	assert.True(t, deleted)
+
+	_, err = ccClient.DeleteZeebeClient(cluster.ID, "")
+	assert.EqualError(t, err, "Client id should not be empty")
+}
+
+func Test_Members(t *testing.T) {
+	srv := cctest.NewServer()
+	defer srv.Close()
+	ccClient := srv.Client()
+
+	_, err := ccClient.InviteMember("jane@example.com", cc.MemberRoleDeveloper)
+	assert.NoError(t, err)
+
+	_, err = ccClient.InviteMember("jane@example.com", cc.MemberRoleDeveloper)
+	httpErr := &cc.HTTPError{}
+	assert.True(t, errors.As(err, &httpErr))
+	assert.Equal(t, 409, httpErr.StatusCode)
+
+	_, err = ccClient.UpdateMemberRoles("jane@example.com", cc.MemberRoleAdmin)
+	assert.NoError(t, err)
+
+	members, err := ccClient.GetMembers()
+	assert.NoError(t, err)
+	assert.Equal(t, []cc.OrganizationMember{{Email: "jane@example.com", Roles: []cc.MemberRole{cc.MemberRoleAdmin}, InvitePending: true}}, members)
+
+	_, err = ccClient.DeleteMember("jane@example.com")
+	assert.NoError(t, err)
+	assert.Empty(t, srv.Members())
+}
+
+func Test_ParseZeebeClientScopes(t *testing.T) {
+	scopes, err := cc.ParseZeebeClientScopes([]string{"zeebe", " Operate", "TASKLIST", ""})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []cc.ZeebeClientScope{cc.ZeebeClientScopeZeebe, cc.ZeebeClientScopeOperate, cc.ZeebeClientScopeTasklist}, scopes)
+
+	_, err = cc.ParseZeebeClientScopes([]string{"zeebe", "console"})
+	assert.EqualError(t, err, "Unknown zeebe client scope: console")
+}
+
+func Test_ParseMemberRoles(t *testing.T) {
+	roles, err := cc.ParseMemberRoles([]string{"Developer", "analyst"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []cc.MemberRole{cc.MemberRoleDeveloper, cc.MemberRoleAnalyst}, roles)
+
+	_, err = cc.ParseMemberRoles([]string{"superuser"})
+	assert.EqualError(t, err, "Unknown member role: superuser")
+}
@@ -0,0 +1,215 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// CloneOptions tells CloneCluster what to recreate on the clone besides its configuration.
+type CloneOptions struct {
+	// ZeebeClients recreates the Zeebe clients with their names and scopes. They get new ids and secrets.
+	ZeebeClients bool
+	// IPAllowlist copies the IP allowlist.
+	IPAllowlist bool
+	// ConnectorSecrets recreates the connector secrets with their names and empty values.
+	ConnectorSecrets bool
+	// PollInterval is the time between two status requests while waiting for the clone to be
+	// healthy before recreating anything on it, DefaultPollInterval when zero.
+	PollInterval time.Duration
+}
+
+// CloneItem is something of the source cluster, and what CloneCluster did with it.
+type CloneItem struct {
+	// What is the kind of item, for example "plan" or "Zeebe client".
+	What string `json:"what"`
+	// Name identifies the item, or gives its value for the configuration.
+	Name string `json:"name,omitempty"`
+	// Details tells how the item was copied, or why it was not.
+	Details string `json:"details,omitempty"`
+	// Error tells why copying the item failed.
+	Error string `json:"error,omitempty"`
+}
+
+// CloneReport tells what CloneCluster copied from the source cluster to the clone.
+type CloneReport struct {
+	SourceID   string      `json:"sourceId"`
+	SourceName string      `json:"sourceName"`
+	ClusterID  string      `json:"clusterId"`
+	Name       string      `json:"name"`
+	Copied     []CloneItem `json:"copied"`
+	NotCopied  []CloneItem `json:"notCopied"`
+	Failed     []CloneItem `json:"failed"`
+}
+
+// Err returns an error summing the items that failed to be copied up, nil when none failed.
+func (r CloneReport) Err() error {
+	if len(r.Failed) == 0 {
+		return nil
+	}
+	failures := []string{}
+	for _, item := range r.Failed {
+		failures = append(failures, strings.TrimSpace(item.What+" "+item.Name)+": "+item.Error)
+	}
+	return fmt.Errorf("failed to copy %d items to cluster %s: %s", len(r.Failed), r.Name, strings.Join(failures, "; "))
+}
+
+// CloneCluster creates an empty cluster named newName with the channel, generation, plan and region
+// of the source cluster and, once it is healthy, recreates what opts asks for. The scopes needed are
+// checked before anything is created. The returned error is about reading the source, creating the
+// clone or waiting for it: the items that failed to be copied are in the report, see CloneReport.Err.
+func (c *CCClient) CloneCluster(ctx context.Context, sourceID string, newName string, opts CloneOptions) (CloneReport, error) {
+
+	if c.tracingEnabled {
+		_, span := c.tracer.Start(ctx, "cloneCluster")
+		defer span.End()
+	}
+
+	report := CloneReport{SourceID: sourceID, Name: newName, Copied: []CloneItem{}, NotCopied: []CloneItem{}, Failed: []CloneItem{}}
+
+	scopes := []Scope{ScopeCluster}
+	if opts.ZeebeClients {
+		scopes = append(scopes, ScopeZeebeClient)
+	}
+	if opts.ConnectorSecrets {
+		scopes = append(scopes, ScopeSecrets)
+	}
+	if err := c.RequireScopes(scopes...); err != nil {
+		return report, err
+	}
+
+	source, err := c.GetClusterWithContext(ctx, sourceID)
+	if err != nil {
+		return report, err
+	}
+	report.SourceName = source.Name
+
+	// Ids first, the names of the source may be outdated.
+	region := source.K8sContext.UUID
+	if region == "" {
+		region = source.K8sContext.Name
+	}
+	resolved, err := c.resolveClusterSpec(ClusterSpec{
+		Name:       newName,
+		Plan:       source.ClusterPlantType.Id,
+		Channel:    source.Channel.Id,
+		Generation: source.Generation.Id,
+		Region:     region,
+	})
+	if err != nil {
+		return report, fmt.Errorf("cannot clone cluster %s: %v", source.Name, err)
+	}
+
+	if report.ClusterID, err = c.createResolvedCluster(ctx, newName, resolved); err != nil {
+		return report, err
+	}
+	report.copied(CloneItem{What: "channel", Name: resolved.channel.Name})
+	report.copied(CloneItem{What: "generation", Name: resolved.generation.Name})
+	report.copied(CloneItem{What: "plan", Name: resolved.plan.Name})
+	report.copied(CloneItem{What: "region", Name: resolved.region.Name})
+	report.notCopied(CloneItem{What: "data", Details: "the clone starts empty"})
+
+	if opts.ZeebeClients || opts.IPAllowlist || opts.ConnectorSecrets {
+		if _, err := c.WaitForClusterHealthy(ctx, report.ClusterID, opts.PollInterval); err != nil {
+			return report, fmt.Errorf("cluster %s was created but nothing was copied to it, it is not healthy: %w", newName, err)
+		}
+	}
+
+	if opts.ZeebeClients {
+		c.cloneZeebeClients(ctx, sourceID, &report)
+	} else {
+		report.notCopied(CloneItem{What: "Zeebe clients", Details: "not requested"})
+	}
+
+	if opts.IPAllowlist {
+		c.cloneIPAllowlist(ctx, source, &report)
+	} else {
+		report.notCopied(CloneItem{What: "IP allowlist", Details: "not requested"})
+	}
+
+	if opts.ConnectorSecrets {
+		c.cloneConnectorSecrets(ctx, sourceID, &report)
+	} else {
+		report.notCopied(CloneItem{What: "connector secrets", Details: "not requested"})
+	}
+
+	return report, nil
+}
+
+func (c *CCClient) cloneZeebeClients(ctx context.Context, sourceID string, report *CloneReport) {
+	clients, err := c.GetZeebeClientsWithContext(ctx, sourceID)
+	if err != nil {
+		report.failed(CloneItem{What: "Zeebe clients"}, err)
+		return
+	}
+	for _, client := range clients {
+		item := CloneItem{What: "Zeebe client", Name: client.Name}
+		if client.Internal {
+			item.Details = "internal clients are managed by Camunda Cloud"
+			report.notCopied(item)
+			continue
+		}
+		if _, err := c.CreateZeebeClientWithContext(ctx, report.ClusterID, client.Name, client.Permissions...); err != nil {
+			report.failed(item, err)
+			continue
+		}
+		item.Details = "scopes " + joinZeebeClientScopes(client.Permissions) + ", new id and secret"
+		report.copied(item)
+	}
+}
+
+func (c *CCClient) cloneIPAllowlist(ctx context.Context, source Cluster, report *CloneReport) {
+	ips := []string{}
+	for _, entry := range source.IPAllowlist {
+		ips = append(ips, entry.IP)
+	}
+	item := CloneItem{What: "IP allowlist", Details: strings.Join(ips, ", ")}
+	if len(source.IPAllowlist) == 0 {
+		item.Details = "empty on the source"
+		report.notCopied(item)
+		return
+	}
+	if _, err := c.SetIPAllowlistWithContext(ctx, report.ClusterID, source.IPAllowlist); err != nil {
+		report.failed(item, err)
+		return
+	}
+	report.copied(item)
+}
+
+func (c *CCClient) cloneConnectorSecrets(ctx context.Context, sourceID string, report *CloneReport) {
+	secrets, err := c.GetConnectorSecretsWithContext(ctx, sourceID)
+	if err != nil {
+		report.failed(CloneItem{What: "connector secrets"}, err)
+		return
+	}
+	names := []string{}
+	for name := range secrets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		item := CloneItem{What: "connector secret", Name: name}
+		if _, err := c.CreateConnectorSecretWithContext(ctx, report.ClusterID, name, ""); err != nil {
+			report.failed(item, err)
+			continue
+		}
+		item.Details = "empty value"
+		report.copied(item)
+	}
+}
+
+func (r *CloneReport) copied(item CloneItem) {
+	r.Copied = append(r.Copied, item)
+}
+
+func (r *CloneReport) notCopied(item CloneItem) {
+	r.NotCopied = append(r.NotCopied, item)
+}
+
+func (r *CloneReport) failed(item CloneItem, err error) {
+	item.Error = err.Error()
+	r.Failed = append(r.Failed, item)
+}
@@ -0,0 +1,152 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/camunda-community-hub/camunda-cloud-go-client/pkg/cc/cctest"
+	cc "github.com/camunda-community-hub/camunda-cloud-go-client/pkg/cc/client"
+	"github.com/stretchr/testify/assert"
+)
+
+// newCloneSource adds a production cluster with Zeebe clients, an IP allowlist and connector secrets.
+func newCloneSource(t *testing.T, srv *cctest.Server) cc.Cluster {
+	params := srv.Params()
+	source := srv.AddCluster(cc.Cluster{
+		Name:             "prod-eu",
+		Channel:          params.Channels[0],
+		Generation:       params.Channels[0].AllowedGeneration[0],
+		ClusterPlantType: params.ClusterPlanTypes[1],
+		K8sContext:       cc.K8sContext{UUID: params.Regions[1].Id, Name: params.Regions[1].Name},
+		IPAllowlist:      []cc.IPAllowlistEntry{{Description: "office", IP: "10.0.0.0/8"}},
+	})
+	if _, err := srv.AddZeebeClient(source.ID, "worker", cc.ZeebeClientScopeZeebe); err != nil {
+		t.Fatal(err)
+	}
+	assert.NoError(t, srv.AddConnectorSecret(source.ID, "SLACK_TOKEN", "xoxb-secret"))
+	assert.NoError(t, srv.AddConnectorSecret(source.ID, "API_KEY", "secret"))
+	return source
+}
+
+func Test_CloneCluster(t *testing.T) {
+	srv := cctest.NewServer()
+	defer srv.Close()
+	ccClient := srv.Client()
+	source := newCloneSource(t, srv)
+
+	report, err := ccClient.CloneCluster(context.Background(), source.ID, "repro",
+		cc.CloneOptions{ZeebeClients: true, IPAllowlist: true, ConnectorSecrets: true, PollInterval: time.Millisecond})
+
+	assert.NoError(t, err)
+	assert.NoError(t, report.Err())
+	assert.Equal(t, "prod-eu", report.SourceName)
+	assert.Equal(t, []cc.CloneItem{
+		{What: "channel", Name: "Stable"},
+		{What: "generation", Name: "Zeebe 0.26.1"},
+		{What: "plan", Name: "Production - S"},
+		{What: "region", Name: "US East"},
+		{What: "Zeebe client", Name: "worker", Details: "scopes Zeebe, new id and secret"},
+		{What: "IP allowlist", Details: "10.0.0.0/8"},
+		{What: "connector secret", Name: "API_KEY", Details: "empty value"},
+		{What: "connector secret", Name: "SLACK_TOKEN", Details: "empty value"},
+	}, report.Copied)
+	assert.Equal(t, []cc.CloneItem{{What: "data", Details: "the clone starts empty"}}, report.NotCopied)
+
+	clone := srv.Clusters()[1]
+	assert.Equal(t, report.ClusterID, clone.ID)
+	assert.Equal(t, "generation-stable-1", clone.Generation.Id)
+	assert.Equal(t, "plan-production-s", clone.ClusterPlantType.Id)
+	assert.Equal(t, "region-us-east1", clone.K8sContext.UUID)
+	assert.Equal(t, source.IPAllowlist, clone.IPAllowlist)
+	assert.Equal(t, map[string]string{"API_KEY": "", "SLACK_TOKEN": ""}, srv.ConnectorSecrets(clone.ID))
+	clients := srv.ZeebeClients(clone.ID)
+	assert.Len(t, clients, 1)
+	assert.Equal(t, []cc.ZeebeClientScope{cc.ZeebeClientScopeZeebe}, clients[0].Permissions)
+
+	routes := []string{}
+	for _, request := range srv.Requests() {
+		if request.Route == "POST /clusters" || request.Params["clusterId"] == clone.ID {
+			routes = append(routes, request.Route)
+		}
+	}
+	assert.Equal(t, []string{"POST /clusters", "GET /clusters/{clusterId}", "GET /clusters/{clusterId}", "POST /clusters/{clusterId}/clients"}, routes[:4],
+		"the clone is healthy before anything is copied to it")
+}
+
+func Test_CloneCluster_configurationOnly(t *testing.T) {
+	srv := cctest.NewServer()
+	defer srv.Close()
+	ccClient := srv.Client()
+	source := newCloneSource(t, srv)
+
+	report, err := ccClient.CloneCluster(context.Background(), source.ID, "repro", cc.CloneOptions{})
+
+	assert.NoError(t, err)
+	assert.Len(t, report.Copied, 4)
+	assert.Equal(t, []cc.CloneItem{
+		{What: "data", Details: "the clone starts empty"},
+		{What: "Zeebe clients", Details: "not requested"},
+		{What: "IP allowlist", Details: "not requested"},
+		{What: "connector secrets", Details: "not requested"},
+	}, report.NotCopied)
+	assert.Empty(t, srv.ZeebeClients(report.ClusterID))
+	assert.Empty(t, srv.RequestsTo("GET /clusters/{clusterId}/secrets"))
+}
+
+func Test_CloneCluster_failedItems(t *testing.T) {
+	srv := cctest.NewServer()
+	defer srv.Close()
+	ccClient := srv.Client()
+	source := newCloneSource(t, srv)
+	srv.InjectFault("POST /clusters/{clusterId}/secrets", cctest.Fail(http.StatusInternalServerError, 1))
+
+	report, err := ccClient.CloneCluster(context.Background(), source.ID, "repro", cc.CloneOptions{ConnectorSecrets: true, PollInterval: time.Millisecond})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []cc.CloneItem{{What: "connector secret", Name: "API_KEY", Error: "HTTP Error trying to createConnectorSecret: 500"}}, report.Failed)
+	assert.EqualError(t, report.Err(), "failed to copy 1 items to cluster repro: connector secret API_KEY: HTTP Error trying to createConnectorSecret: 500")
+	assert.Equal(t, map[string]string{"SLACK_TOKEN": ""}, srv.ConnectorSecrets(report.ClusterID))
+}
+
+func Test_CloneCluster_rejectedZeebeClient(t *testing.T) {
+	srv := cctest.NewServer()
+	defer srv.Close()
+	ccClient := srv.Client()
+	source := newCloneSource(t, srv)
+	srv.InjectFault("POST /clusters/{clusterId}/clients", cctest.Fail(http.StatusForbidden, 1))
+
+	report, err := ccClient.CloneCluster(context.Background(), source.ID, "repro", cc.CloneOptions{ZeebeClients: true, PollInterval: time.Millisecond})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []cc.CloneItem{{What: "Zeebe client", Name: "worker", Error: "HTTP Error trying to createZeebeClient: 403"}}, report.Failed)
+	assert.Len(t, report.Copied, 4, "only the configuration")
+	assert.Empty(t, srv.ZeebeClients(report.ClusterID))
+}
+
+func Test_CloneCluster_missingScope(t *testing.T) {
+	srv := cctest.NewServer()
+	defer srv.Close()
+	srv.Scope = "Cluster"
+	ccClient := srv.Client()
+	source := newCloneSource(t, srv)
+
+	_, err := ccClient.CloneCluster(context.Background(), source.ID, "repro", cc.CloneOptions{ZeebeClients: true})
+
+	assert.True(t, errors.Is(err, cc.ErrMissingScope))
+	assert.Empty(t, srv.RequestsTo("POST /clusters"))
+	assert.Len(t, srv.Clusters(), 1)
+}
+
+func Test_CloneCluster_unknownSource(t *testing.T) {
+	srv := cctest.NewServer()
+	defer srv.Close()
+	ccClient := srv.Client()
+
+	_, err := ccClient.CloneCluster(context.Background(), "missing", "repro", cc.CloneOptions{})
+
+	assert.EqualError(t, err, "HTTP Error trying to getCluster: 404")
+	assert.Empty(t, srv.Clusters())
+}
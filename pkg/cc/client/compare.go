@@ -0,0 +1,242 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+)
+
+// OrganizationSnapshot is what CompareOrganizations compares of an organization.
+type OrganizationSnapshot struct {
+	// Name tells the organizations apart in a CompareReport, for example the name of a context.
+	Name     string            `json:"name"`
+	Clusters []ClusterSnapshot `json:"clusters"`
+}
+
+// ClusterSnapshot is a cluster with its Zeebe clients.
+type ClusterSnapshot struct {
+	Cluster      Cluster               `json:"cluster"`
+	ZeebeClients []ZeebeClientResponse `json:"zeebeClients"`
+}
+
+// SnapshotOrganization lists the clusters of the organization with their Zeebe clients, sorted by name.
+// The names of plans, regions, channels and generations missing from the listing are filled from the
+// cluster parameters of the organization.
+func (c *CCClient) SnapshotOrganization(ctx context.Context, name string) (OrganizationSnapshot, error) {
+
+	if c.tracingEnabled {
+		_, span := c.tracer.Start(ctx, "snapshotOrganization")
+		defer span.End()
+	}
+
+	snapshot := OrganizationSnapshot{Name: name, Clusters: []ClusterSnapshot{}}
+
+	params, err := c.GetClusterParamsWithContext(ctx)
+	if err != nil {
+		return snapshot, err
+	}
+	clusters, err := c.ListClusters(ctx, ListOptions{SortBy: SortByName})
+	if err != nil {
+		return snapshot, err
+	}
+	clients, err := c.zeebeClientsOf(ctx, clusters, 0)
+	if err != nil {
+		return snapshot, err
+	}
+	for i, cluster := range clusters {
+		snapshot.Clusters = append(snapshot.Clusters, ClusterSnapshot{Cluster: resolveClusterNames(cluster, *params), ZeebeClients: clients[i]})
+	}
+	return snapshot, nil
+}
+
+// CompareOptions tells CompareOrganizations how to match the clusters of the organizations.
+type CompareOptions struct {
+	// NamePattern extracts the part of the cluster names clusters are matched on: its first group,
+	// or the whole match when it has none. For example ^(?:dev|prod)-(.*)$ matches dev-orders with
+	// prod-orders. Names it does not match are used whole. Nil matches clusters with the same name.
+	NamePattern *regexp.Regexp
+}
+
+// MatchName returns the name the cluster is matched on.
+func (o CompareOptions) MatchName(name string) string {
+	if o.NamePattern == nil {
+		return name
+	}
+	match := o.NamePattern.FindStringSubmatch(name)
+	switch {
+	case match == nil:
+		return name
+	case len(match) > 1:
+		return match[1]
+	}
+	return match[0]
+}
+
+// Difference is something that is not the same in all the organizations compared.
+type Difference struct {
+	// Cluster is the name the clusters are matched on.
+	Cluster string `json:"cluster"`
+	// What is what differs: cluster when it is missing from an organization, generation, channel,
+	// plan, region, Zeebe client or IP allowlist.
+	What string `json:"what"`
+	// Name is the name of the Zeebe client or the IP of the allowlist entry.
+	Name string `json:"name,omitempty"`
+	// Values are the values in each organization of the report, empty when missing.
+	Values []string `json:"values"`
+}
+
+// CompareReport lists the differences between the clusters of organizations.
+type CompareReport struct {
+	Organizations []string `json:"organizations"`
+	// Matched is the number of clusters found in all the organizations.
+	Matched     int          `json:"matched"`
+	Differences []Difference `json:"differences"`
+}
+
+// CompareOrganizations matches the clusters of the organizations by name, see CompareOptions, and reports
+// the clusters missing from some of them, and the differences in generation, channel, plan, region,
+// Zeebe client names and scopes and IP allowlist entries of the others. Internal Zeebe clients are ignored.
+func CompareOrganizations(organizations []OrganizationSnapshot, opts CompareOptions) (CompareReport, error) {
+	report := CompareReport{Organizations: []string{}, Differences: []Difference{}}
+
+	// matched holds the clusters of each organization by the name they are matched on.
+	matched := make([]map[string]ClusterSnapshot, len(organizations))
+	names := []string{}
+	for i, organization := range organizations {
+		report.Organizations = append(report.Organizations, organization.Name)
+		matched[i] = map[string]ClusterSnapshot{}
+		for _, cluster := range organization.Clusters {
+			name := opts.MatchName(cluster.Cluster.Name)
+			if other, found := matched[i][name]; found {
+				return report, fmt.Errorf("clusters %s and %s of %s both match %s",
+					other.Cluster.Name, cluster.Cluster.Name, organization.Name, name)
+			}
+			matched[i][name] = cluster
+			if !containsString(names, name) {
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		clusters := make([]*ClusterSnapshot, len(organizations))
+		missing := false
+		for i := range organizations {
+			if cluster, found := matched[i][name]; found {
+				clusters[i] = &cluster
+			} else {
+				missing = true
+			}
+		}
+
+		if missing {
+			report.add(Difference{Cluster: name, What: "cluster"}, clusters, func(c *ClusterSnapshot) string { return c.Cluster.Name })
+			continue
+		}
+		report.Matched++
+
+		report.add(Difference{Cluster: name, What: "generation"}, clusters, func(c *ClusterSnapshot) string {
+			return nameOrID(c.Cluster.Generation.Name, c.Cluster.Generation.Id)
+		})
+		report.add(Difference{Cluster: name, What: "channel"}, clusters, func(c *ClusterSnapshot) string {
+			return nameOrID(c.Cluster.Channel.Name, c.Cluster.Channel.Id)
+		})
+		report.add(Difference{Cluster: name, What: "plan"}, clusters, func(c *ClusterSnapshot) string {
+			return nameOrID(c.Cluster.ClusterPlantType.Name, c.Cluster.ClusterPlantType.Id)
+		})
+		report.add(Difference{Cluster: name, What: "region"}, clusters, func(c *ClusterSnapshot) string {
+			return nameOrID(c.Cluster.K8sContext.Name, c.Cluster.K8sContext.UUID)
+		})
+
+		for _, client := range sortedUnion(clusters, zeebeClientNames) {
+			report.add(Difference{Cluster: name, What: "Zeebe client", Name: client}, clusters, func(c *ClusterSnapshot) string {
+				for _, zc := range c.ZeebeClients {
+					if !zc.Internal && zc.Name == client {
+						return joinZeebeClientScopes(zc.Permissions)
+					}
+				}
+				return ""
+			})
+		}
+
+		for _, ip := range sortedUnion(clusters, allowlistIPs) {
+			report.add(Difference{Cluster: name, What: "IP allowlist", Name: ip}, clusters, func(c *ClusterSnapshot) string {
+				for _, entry := range c.Cluster.IPAllowlist {
+					if entry.IP == ip {
+						return "allowed"
+					}
+				}
+				return ""
+			})
+		}
+	}
+	return report, nil
+}
+
+// add adds the difference with the value of each cluster, nil clusters having none, unless all the values are the same.
+func (r *CompareReport) add(difference Difference, clusters []*ClusterSnapshot, value func(c *ClusterSnapshot) string) {
+	difference.Values = []string{}
+	same := true
+	for _, cluster := range clusters {
+		v := ""
+		if cluster != nil {
+			v = value(cluster)
+		}
+		same = same && (len(difference.Values) == 0 || v == difference.Values[0])
+		difference.Values = append(difference.Values, v)
+	}
+	if !same {
+		r.Differences = append(r.Differences, difference)
+	}
+}
+
+// nameOrID returns the name, or the id when the name is not known.
+func nameOrID(name string, id string) string {
+	if name != "" {
+		return name
+	}
+	return id
+}
+
+func zeebeClientNames(c *ClusterSnapshot) []string {
+	names := []string{}
+	for _, client := range c.ZeebeClients {
+		if !client.Internal {
+			names = append(names, client.Name)
+		}
+	}
+	return names
+}
+
+func allowlistIPs(c *ClusterSnapshot) []string {
+	ips := []string{}
+	for _, entry := range c.Cluster.IPAllowlist {
+		ips = append(ips, entry.IP)
+	}
+	return ips
+}
+
+// sortedUnion returns the sorted union of the values of the clusters.
+func sortedUnion(clusters []*ClusterSnapshot, values func(c *ClusterSnapshot) []string) []string {
+	union := []string{}
+	for _, cluster := range clusters {
+		for _, value := range values(cluster) {
+			if !containsString(union, value) {
+				union = append(union, value)
+			}
+		}
+	}
+	sort.Strings(union)
+	return union
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,78 @@
+package client
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CompareOrganizations(t *testing.T) {
+	cluster := func(name string, generation string, plan string, ips ...string) ClusterSnapshot {
+		c := ClusterSnapshot{Cluster: Cluster{
+			Name:             name,
+			Channel:          Channel{Name: "Stable"},
+			Generation:       Generation{Name: generation},
+			ClusterPlantType: ClusterPlantType{Name: plan},
+			K8sContext:       K8sContext{UUID: "region-europe-west1", Name: "Europe West"},
+		}}
+		for _, ip := range ips {
+			c.Cluster.IPAllowlist = append(c.Cluster.IPAllowlist, IPAllowlistEntry{IP: ip})
+		}
+		return c
+	}
+	client := func(name string, scopes ...ZeebeClientScope) ZeebeClientResponse {
+		return ZeebeClientResponse{Name: name, Permissions: scopes}
+	}
+
+	devOrders := cluster("dev-orders", "Zeebe 1.0.0", "Development", "10.0.0.0/8")
+	devOrders.ZeebeClients = []ZeebeClientResponse{client("worker", ZeebeClientScopeZeebe), client("starter", ZeebeClientScopeZeebe),
+		{Name: "operate", Internal: true}}
+	prodOrders := cluster("prod-orders", "Zeebe 0.26.1", "Production - S", "10.0.0.0/8", "192.168.1.1")
+	prodOrders.ZeebeClients = []ZeebeClientResponse{client("worker", ZeebeClientScopeOperate, ZeebeClientScopeZeebe)}
+	dev := OrganizationSnapshot{Name: "dev", Clusters: []ClusterSnapshot{devOrders, cluster("dev-same", "Zeebe 1.0.0", "Development")}}
+	prod := OrganizationSnapshot{Name: "prod", Clusters: []ClusterSnapshot{prodOrders, cluster("prod-same", "Zeebe 1.0.0", "Development"),
+		cluster("billing", "Zeebe 1.0.0", "Development")}}
+
+	report, err := CompareOrganizations([]OrganizationSnapshot{dev, prod}, CompareOptions{NamePattern: regexp.MustCompile(`^(?:dev|prod)-(.*)$`)})
+
+	assert.NoError(t, err)
+	assert.Equal(t, CompareReport{Organizations: []string{"dev", "prod"}, Matched: 2, Differences: []Difference{
+		{Cluster: "billing", What: "cluster", Values: []string{"", "billing"}},
+		{Cluster: "orders", What: "generation", Values: []string{"Zeebe 1.0.0", "Zeebe 0.26.1"}},
+		{Cluster: "orders", What: "plan", Values: []string{"Development", "Production - S"}},
+		{Cluster: "orders", What: "Zeebe client", Name: "starter", Values: []string{"Zeebe", ""}},
+		{Cluster: "orders", What: "Zeebe client", Name: "worker", Values: []string{"Zeebe", "Operate,Zeebe"}},
+		{Cluster: "orders", What: "IP allowlist", Name: "192.168.1.1", Values: []string{"", "allowed"}},
+	}}, report)
+
+	report, err = CompareOrganizations([]OrganizationSnapshot{dev, prod}, CompareOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, report.Matched, "without a pattern, clusters are matched by name")
+	assert.Len(t, report.Differences, 5)
+
+	_, err = CompareOrganizations([]OrganizationSnapshot{prod}, CompareOptions{NamePattern: regexp.MustCompile(`^[a-z]+`)})
+	assert.EqualError(t, err, "clusters prod-orders and prod-same of prod both match prod")
+}
+
+func Test_CompareOrganizations_idsWithoutNames(t *testing.T) {
+	cluster := func(org string, generationID string) OrganizationSnapshot {
+		return OrganizationSnapshot{Name: org, Clusters: []ClusterSnapshot{{Cluster: Cluster{Name: "orders",
+			Channel: Channel{Id: "channel-stable"}, Generation: Generation{Id: generationID}}}}}
+	}
+
+	report, err := CompareOrganizations([]OrganizationSnapshot{cluster("dev", "generation-2"), cluster("prod", "generation-1")}, CompareOptions{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []Difference{{Cluster: "orders", What: "generation", Values: []string{"generation-2", "generation-1"}}}, report.Differences)
+}
+
+func Test_CompareOptions_MatchName(t *testing.T) {
+	opts := CompareOptions{NamePattern: regexp.MustCompile(`^(?:dev|prod)-(.*)$`)}
+	assert.Equal(t, "orders", opts.MatchName("prod-orders"))
+	assert.Equal(t, "billing", opts.MatchName("billing"))
+
+	opts = CompareOptions{NamePattern: regexp.MustCompile(`[a-z]+$`)}
+	assert.Equal(t, "orders", opts.MatchName("eu-orders"))
+	assert.Equal(t, "eu-orders", CompareOptions{}.MatchName("eu-orders"))
+}
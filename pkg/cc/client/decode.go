@@ -0,0 +1,255 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultMaxResponseBytes bounds the size of the responses the client reads.
+const DefaultMaxResponseBytes = 10 << 20
+
+// ErrResponseTooLarge is returned when a response is larger than the configured maximum.
+var ErrResponseTooLarge = errors.New("response body too large")
+
+// SchemaDrift describes a response that does not match the types of this package.
+type SchemaDrift struct {
+	// Operation is the request that got the response, for example "getClusters".
+	Operation string
+	// UnknownFields are fields of the response the types do not know about, for example "[].links.console".
+	UnknownFields []string
+	// MissingFields are fields of the types, without omitempty, that the response does not have.
+	MissingFields []string
+}
+
+func (d SchemaDrift) String() string {
+	return d.Operation + ": " + d.Details()
+}
+
+// Details lists the unknown and missing fields.
+func (d SchemaDrift) Details() string {
+	parts := []string{}
+	if len(d.UnknownFields) > 0 {
+		parts = append(parts, "unknown fields: "+strings.Join(d.UnknownFields, ", "))
+	}
+	if len(d.MissingFields) > 0 {
+		parts = append(parts, "missing fields: "+strings.Join(d.MissingFields, ", "))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// LogSchemaDrift is a schema drift handler that logs the drift.
+func LogSchemaDrift(drift SchemaDrift) {
+	log.Printf("schema drift in %s", drift)
+}
+
+// SetSchemaDriftHandler enables strict decoding: every response is compared with the type
+// it is decoded into, and the handler is called when they differ. Decoding itself still
+// succeeds, so drift is reported without breaking callers. A nil handler disables it.
+func (c *CCClient) SetSchemaDriftHandler(handler func(SchemaDrift)) {
+	c.schemaDriftHandler = handler
+}
+
+// SetMaxResponseBytes bounds the size of the responses, DefaultMaxResponseBytes by default.
+func (c *CCClient) SetMaxResponseBytes(maxResponseBytes int64) {
+	c.maxResponseBytes = maxResponseBytes
+}
+
+func (c *CCClient) limitBody(body io.Reader) io.Reader {
+	max := c.maxResponseBytes
+	if max <= 0 {
+		max = DefaultMaxResponseBytes
+	}
+	return &limitedReader{r: body, max: max, remaining: max}
+}
+
+// readBody reads a whole response body, within the size bound.
+func (c *CCClient) readBody(body io.Reader) ([]byte, error) {
+	return ioutil.ReadAll(c.limitBody(body))
+}
+
+// decodeBody decodes a JSON response body into out. Without a schema drift handler the body is
+// streamed into the decoder, otherwise it is read to be compared with the type of out.
+func (c *CCClient) decodeBody(operation string, body io.Reader, out interface{}) error {
+	handler := c.schemaDriftHandler
+	if handler == nil {
+		return json.NewDecoder(c.limitBody(body)).Decode(out)
+	}
+
+	data, err := c.readBody(body)
+	if err != nil {
+		return err
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		// Same as the streaming decoder on an empty body.
+		return io.EOF
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return err
+	}
+
+	var generic interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&generic); err != nil {
+		return err
+	}
+	drift := SchemaDrift{Operation: operation}
+	unknown, missing := map[string]bool{}, map[string]bool{}
+	compareSchema("", generic, reflect.TypeOf(out), unknown, missing)
+	drift.UnknownFields = sortedKeys(unknown)
+	drift.MissingFields = sortedKeys(missing)
+	if len(drift.UnknownFields) > 0 || len(drift.MissingFields) > 0 {
+		handler(drift)
+	}
+	return nil
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// compareSchema walks a decoded JSON value along the Go type it was decoded into.
+func compareSchema(path string, value interface{}, t reflect.Type, unknown map[string]bool, missing map[string]bool) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		object, isObject := value.(map[string]interface{})
+		if !isObject || t == timeType {
+			return
+		}
+		fields := jsonFields(t)
+		for key, field := range object {
+			matched := false
+			for _, f := range fields {
+				// encoding/json matches keys case insensitively, so does the comparison.
+				if strings.EqualFold(f.name, key) {
+					compareSchema(joinPath(path, f.name), field, f.typ, unknown, missing)
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				unknown[joinPath(path, key)] = true
+			}
+		}
+		for _, f := range fields {
+			if f.omitempty {
+				continue
+			}
+			found := false
+			for key := range object {
+				if strings.EqualFold(f.name, key) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				missing[joinPath(path, f.name)] = true
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		items, isArray := value.([]interface{})
+		if !isArray {
+			return
+		}
+		for _, item := range items {
+			compareSchema(path+"[]", item, t.Elem(), unknown, missing)
+		}
+	case reflect.Map:
+		object, isObject := value.(map[string]interface{})
+		if !isObject {
+			return
+		}
+		for _, item := range object {
+			compareSchema(joinPath(path, "*"), item, t.Elem(), unknown, missing)
+		}
+	}
+}
+
+type jsonField struct {
+	name      string
+	typ       reflect.Type
+	omitempty bool
+}
+
+// jsonFields lists the fields of a struct the way encoding/json sees them.
+func jsonFields(t reflect.Type) []jsonField {
+	fields := []jsonField{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		if f.Anonymous && tag == "" && f.Type.Kind() == reflect.Struct {
+			fields = append(fields, jsonFields(f.Type)...)
+			continue
+		}
+		if f.PkgPath != "" {
+			continue
+		}
+		parts := strings.Split(tag, ",")
+		field := jsonField{name: parts[0], typ: f.Type}
+		if field.name == "" {
+			field.name = f.Name
+		}
+		for _, option := range parts[1:] {
+			if option == "omitempty" {
+				field.omitempty = true
+			}
+		}
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+func joinPath(path string, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := []string{}
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// limitedReader fails with ErrResponseTooLarge instead of silently truncating like io.LimitReader.
+type limitedReader struct {
+	r         io.Reader
+	max       int64
+	remaining int64
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	if l.remaining <= 0 {
+		// Only fail when there is more to read than allowed.
+		var probe [1]byte
+		n, err := l.r.Read(probe[:])
+		if n > 0 {
+			return 0, fmt.Errorf("%w: more than %d bytes", ErrResponseTooLarge, l.max)
+		}
+		return 0, err
+	}
+	if int64(len(p)) > l.remaining {
+		p = p[:l.remaining]
+	}
+	n, err := l.r.Read(p)
+	l.remaining -= int64(n)
+	return n, err
+}
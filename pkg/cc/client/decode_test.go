@@ -0,0 +1,55 @@
+package client
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_decodeBody_schemaDrift(t *testing.T) {
+	drifts := []SchemaDrift{}
+	c := &CCClient{}
+	c.SetSchemaDriftHandler(func(drift SchemaDrift) { drifts = append(drifts, drift) })
+
+	clusters := []Cluster{}
+	err := c.decodeBody("getClusters", strings.NewReader(`[
+		{"uuid": "1", "name": "a", "console": "x", "links": {"zeebe": "z", "connectors": "c"}},
+		{"uuid": "2", "name": "b", "console": "y"}
+	]`), &clusters)
+
+	assert.NoError(t, err)
+	assert.Len(t, clusters, 2)
+	assert.Len(t, drifts, 1)
+	assert.Equal(t, "getClusters", drifts[0].Operation)
+	assert.Equal(t, []string{"[].console", "[].links.connectors"}, drifts[0].UnknownFields)
+	assert.Contains(t, drifts[0].MissingFields, "[].channel")
+	assert.Contains(t, drifts[0].MissingFields, "[].links.operate")
+	assert.NotContains(t, drifts[0].MissingFields, "[].status.optimizeStatus", "omitempty fields are optional")
+}
+
+func Test_decodeBody_noDrift(t *testing.T) {
+	called := false
+	c := &CCClient{}
+	c.SetSchemaDriftHandler(func(SchemaDrift) { called = true })
+
+	payload := AuthResponsePayload{}
+	err := c.decodeBody("login", strings.NewReader(`{"ACCESS_TOKEN": "t", "scope": "", "expires_in": 60, "token_type": "Bearer"}`), &payload)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "t", payload.AccessToken)
+	assert.False(t, called, "keys match case insensitively, like encoding/json")
+}
+
+func Test_decodeBody_tooLarge(t *testing.T) {
+	c := &CCClient{}
+	c.SetMaxResponseBytes(8)
+
+	out := map[string]string{}
+	err := c.decodeBody("getClusters", strings.NewReader(`{"name": "too long"}`), &out)
+	assert.True(t, errors.Is(err, ErrResponseTooLarge), err)
+
+	c.SetMaxResponseBytes(9)
+	assert.NoError(t, c.decodeBody("getClusters", strings.NewReader(`{"a":"b"}`), &out))
+}
@@ -0,0 +1,233 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ErrDrift is matched by the DriftError of EnsureCluster and EnsureZeebeClient.
+var ErrDrift = errors.New("existing resource differs from the spec")
+
+// FieldDrift is a field of an existing resource that differs from the spec.
+type FieldDrift struct {
+	Field string `json:"field"`
+	Want  string `json:"want"`
+	Got   string `json:"got"`
+}
+
+// DriftError is returned when a resource with the name of the spec exists with other settings.
+type DriftError struct {
+	// Resource is "cluster" or "Zeebe client".
+	Resource string
+	Name     string
+	ID       string
+	Fields   []FieldDrift
+}
+
+func (e *DriftError) Error() string {
+	drifts := []string{}
+	for _, field := range e.Fields {
+		drifts = append(drifts, fmt.Sprintf("%s is %s, want %s", field.Field, valueOrNone(field.Got), valueOrNone(field.Want)))
+	}
+	return fmt.Sprintf("%s %s (%s) already exists with other settings: %s", e.Resource, e.Name, e.ID, strings.Join(drifts, "; "))
+}
+
+// Is makes errors.Is(err, ErrDrift) true for drift errors.
+func (e *DriftError) Is(target error) bool {
+	return target == ErrDrift
+}
+
+// EnsureCluster returns the cluster named spec.Name when its plan, channel, generation and region
+// match the spec, a *DriftError when they do not, and creates the cluster when there is none.
+// Clusters being deleted are ignored. The returned bool tells whether the cluster was created.
+//
+// Camunda Cloud does not reject clusters with the name of an existing one, so concurrent calls may
+// both create the cluster. After creating, the clusters with the name are listed again and when
+// there are several, the cluster just created is deleted unless it is the oldest, which every call
+// returns.
+func (c *CCClient) EnsureCluster(ctx context.Context, spec ClusterSpec) (Cluster, bool, error) {
+
+	if c.tracingEnabled {
+		_, span := c.tracer.Start(ctx, "ensureCluster")
+		defer span.End()
+	}
+
+	if spec.Name == "" {
+		return Cluster{}, false, NewError("Cluster name should not be empty")
+	}
+
+	resolved, err := c.resolveClusterSpec(spec)
+	if err != nil {
+		return Cluster{}, false, err
+	}
+
+	existing, err := c.liveClustersNamed(ctx, spec.Name)
+	if err != nil {
+		return Cluster{}, false, err
+	}
+	if len(existing) > 1 {
+		return Cluster{}, false, fmt.Errorf("%w: %s", ErrAmbiguousName, spec.Name)
+	}
+	if len(existing) == 1 {
+		return existing[0], false, resolved.drift(existing[0])
+	}
+
+	// Clusters being deleted would make the checks of CreateClusterCustomConfig refuse the name.
+	clusterID, err := c.createCluster(ctx, NewClusterCreationParams(spec.Name,
+		resolved.channel.Id, resolved.generation.Id, resolved.region.Id, resolved.plan.Id))
+	if err != nil {
+		return Cluster{}, false, err
+	}
+	if clusterID == "" {
+		return Cluster{}, false, NewError("Camunda Cloud did not return the id of the created cluster " + spec.Name)
+	}
+
+	created := Cluster{
+		ID:               clusterID,
+		Name:             spec.Name,
+		Channel:          resolved.channel,
+		Generation:       resolved.generation,
+		ClusterPlantType: resolved.plan,
+		K8sContext:       K8sContext{UUID: resolved.region.Id, Name: resolved.region.Name},
+		Status:           ClusterStatus{Ready: HealthCreating},
+	}
+
+	oldest, err := c.oldestClusterNamed(ctx, spec.Name)
+	if err != nil {
+		return created, true, fmt.Errorf("cluster %s (%s) was created but could not be checked for duplicates: %w", spec.Name, clusterID, err)
+	}
+	if oldest.ID == "" || oldest.ID == clusterID {
+		return created, true, nil
+	}
+
+	// Created concurrently with oldest, which wins.
+	if _, err := c.DeleteClusterWithContext(ctx, clusterID); err != nil {
+		return created, true, fmt.Errorf("cluster %s (%s) was created along with %s but could not be deleted: %w", spec.Name, clusterID, oldest.ID, err)
+	}
+	return oldest, false, resolved.drift(oldest)
+}
+
+// oldestClusterNamed returns the first created cluster with exactly this name that is not being
+// deleted, the smallest id when several were created at the same time, or an empty cluster when there is none.
+func (c *CCClient) oldestClusterNamed(ctx context.Context, name string) (Cluster, error) {
+	clusters, err := c.liveClustersNamed(ctx, name)
+	if err != nil || len(clusters) == 0 {
+		return Cluster{}, err
+	}
+
+	sort.Slice(clusters, func(i, j int) bool {
+		if !clusters[i].Created.Equal(clusters[j].Created) {
+			return clusters[i].Created.Before(clusters[j].Created)
+		}
+		return clusters[i].ID < clusters[j].ID
+	})
+	return clusters[0], nil
+}
+
+// liveClustersNamed returns the clusters with exactly this name, except the ones being deleted.
+func (c *CCClient) liveClustersNamed(ctx context.Context, name string) ([]Cluster, error) {
+	clusters, err := c.ListClusters(ctx, ListOptions{Name: name})
+	if err != nil {
+		return nil, err
+	}
+	live := []Cluster{}
+	for _, cluster := range clusters {
+		if cluster.Health() != HealthDeleting {
+			live = append(live, cluster)
+		}
+	}
+	return live, nil
+}
+
+// drift compares an existing cluster with the resolved spec, nil when they match.
+func (r resolvedClusterSpec) drift(cluster Cluster) error {
+	fields := []FieldDrift{}
+	compare := func(field, wantID, wantName, gotID, gotName string) {
+		if (gotID != "" && gotID == wantID) || strings.EqualFold(gotName, wantName) {
+			return
+		}
+		fields = append(fields, FieldDrift{Field: field, Want: wantName, Got: gotName})
+	}
+	compare("plan", r.plan.Id, r.plan.Name, cluster.ClusterPlantType.Id, cluster.ClusterPlantType.Name)
+	compare("channel", r.channel.Id, r.channel.Name, cluster.Channel.Id, cluster.Channel.Name)
+	compare("generation", r.generation.Id, r.generation.Name, cluster.Generation.Id, cluster.Generation.Name)
+	compare("region", r.region.Id, r.region.Name, cluster.K8sContext.UUID, cluster.K8sContext.Name)
+
+	if len(fields) == 0 {
+		return nil
+	}
+	return &DriftError{Resource: "cluster", Name: cluster.Name, ID: cluster.ID, Fields: fields}
+}
+
+// EnsureZeebeClient returns the Zeebe client of the cluster named name when it has the scopes,
+// a *DriftError when it has others, and creates it when there is none. Without scopes, the scopes
+// of an existing client are not compared. The secret is only known, and returned, when the client
+// is created: the returned bool tells whether it was.
+func (c *CCClient) EnsureZeebeClient(ctx context.Context, clusterID string, name string, scopes ...ZeebeClientScope) (ZeebeClientCreatedResponse, bool, error) {
+
+	if c.tracingEnabled {
+		_, span := c.tracer.Start(ctx, "ensureZeebeClient")
+		defer span.End()
+	}
+
+	if len(name) == 0 {
+		return ZeebeClientCreatedResponse{}, false, NewError("Client name should not be empty")
+	}
+
+	clients, err := c.GetZeebeClientsWithContext(ctx, clusterID)
+	if err != nil {
+		return ZeebeClientCreatedResponse{}, false, err
+	}
+
+	for _, existing := range clients {
+		if existing.Name != name {
+			continue
+		}
+		found := ZeebeClientCreatedResponse{Name: existing.Name, ClientID: existing.ClientID}
+		if len(scopes) > 0 && !sameZeebeClientScopes(existing.Permissions, scopes) {
+			return found, false, &DriftError{Resource: "Zeebe client", Name: name, ID: existing.ClientID, Fields: []FieldDrift{{
+				Field: "scopes",
+				Want:  joinZeebeClientScopes(scopes),
+				Got:   joinZeebeClientScopes(existing.Permissions),
+			}}}
+		}
+		return found, false, nil
+	}
+
+	created, err := c.CreateZeebeClientWithContext(ctx, clusterID, name, scopes...)
+	if err != nil {
+		return created, false, err
+	}
+	return created, true, nil
+}
+
+func sameZeebeClientScopes(a []ZeebeClientScope, b []ZeebeClientScope) bool {
+	return joinZeebeClientScopes(a) == joinZeebeClientScopes(b)
+}
+
+// joinZeebeClientScopes sorts and deduplicates the scopes, ignoring the case, into a comma separated list.
+func joinZeebeClientScopes(scopes []ZeebeClientScope) string {
+	seen := map[ZeebeClientScope]bool{}
+	names := []string{}
+	for _, scope := range scopes {
+		if known, ok := findZeebeClientScope(string(scope)); ok {
+			scope = known
+		}
+		if !seen[scope] {
+			seen[scope] = true
+			names = append(names, string(scope))
+		}
+	}
+	sort.Strings(names)
+	return strings.Join(names, ",")
+}
+
+func valueOrNone(value string) string {
+	if value == "" {
+		return "none"
+	}
+	return value
+}
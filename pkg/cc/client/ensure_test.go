@@ -0,0 +1,162 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/camunda-community-hub/camunda-cloud-go-client/pkg/cc/cctest"
+	cc "github.com/camunda-community-hub/camunda-cloud-go-client/pkg/cc/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_EnsureCluster(t *testing.T) {
+	srv := cctest.NewServer()
+	defer srv.Close()
+	ccClient := srv.Client()
+	spec := cc.ClusterSpec{Name: "orders", Plan: "Production - S", Region: "US East"}
+
+	created, isNew, err := ccClient.EnsureCluster(context.Background(), spec)
+	assert.NoError(t, err)
+	assert.True(t, isNew)
+	assert.Equal(t, "Production - S", created.ClusterPlantType.Name)
+	assert.Equal(t, "US East", created.K8sContext.Name)
+
+	existing, isNew, err := ccClient.EnsureCluster(context.Background(), spec)
+	assert.NoError(t, err)
+	assert.False(t, isNew)
+	assert.Equal(t, created.ID, existing.ID)
+	assert.Len(t, srv.Clusters(), 1)
+	assert.Len(t, srv.RequestsTo("POST /clusters"), 1)
+}
+
+func Test_EnsureCluster_drift(t *testing.T) {
+	srv := cctest.NewServer()
+	defer srv.Close()
+	ccClient := srv.Client()
+	clusterID, _ := ccClient.CreateClusterDefault("orders")
+
+	_, isNew, err := ccClient.EnsureCluster(context.Background(), cc.ClusterSpec{Name: "orders", Plan: "Production - S", Channel: "Alpha"})
+
+	assert.False(t, isNew)
+	assert.True(t, errors.Is(err, cc.ErrDrift))
+	drift := &cc.DriftError{}
+	assert.True(t, errors.As(err, &drift))
+	assert.Equal(t, clusterID, drift.ID)
+	assert.Equal(t, []cc.FieldDrift{
+		{Field: "plan", Want: "Production - S", Got: "Development"},
+		{Field: "channel", Want: "Alpha", Got: "Stable"},
+		{Field: "generation", Want: "Zeebe 1.1.0-alpha1", Got: "Zeebe 1.0.0"},
+	}, drift.Fields)
+	assert.Equal(t, "cluster orders ("+clusterID+") already exists with other settings: "+
+		"plan is Development, want Production - S; channel is Stable, want Alpha; generation is Zeebe 1.0.0, want Zeebe 1.1.0-alpha1",
+		err.Error())
+}
+
+func Test_EnsureZeebeClient(t *testing.T) {
+	srv := cctest.NewServer()
+	defer srv.Close()
+	ccClient := srv.Client()
+	clusterID, _ := ccClient.CreateClusterDefault("orders")
+
+	created, isNew, err := ccClient.EnsureZeebeClient(context.Background(), clusterID, "worker", cc.ZeebeClientScopeZeebe)
+	assert.NoError(t, err)
+	assert.True(t, isNew)
+	assert.NotEmpty(t, created.ClientSecret)
+
+	existing, isNew, err := ccClient.EnsureZeebeClient(context.Background(), clusterID, "worker", "zeebe")
+	assert.NoError(t, err)
+	assert.False(t, isNew)
+	assert.Equal(t, cc.ZeebeClientCreatedResponse{Name: "worker", ClientID: created.ClientID}, existing)
+
+	_, _, err = ccClient.EnsureZeebeClient(context.Background(), clusterID, "worker", cc.ZeebeClientScopeZeebe, cc.ZeebeClientScopeOperate)
+	assert.True(t, errors.Is(err, cc.ErrDrift))
+	assert.EqualError(t, err, "Zeebe client worker ("+created.ClientID+") already exists with other settings: scopes is Zeebe, want Operate,Zeebe")
+	assert.Len(t, srv.ZeebeClients(clusterID), 1)
+}
+
+func Test_EnsureCluster_unknownParam(t *testing.T) {
+	srv := cctest.NewServer()
+	defer srv.Close()
+	ccClient := srv.Client()
+
+	_, _, err := ccClient.EnsureCluster(context.Background(), cc.ClusterSpec{Name: "orders", Region: "Mars"})
+
+	assert.EqualError(t, err, "No region found with name or id: Mars")
+	assert.Empty(t, srv.Clusters())
+}
+
+func Test_EnsureCluster_concurrentCreation(t *testing.T) {
+	for name, test := range map[string]struct {
+		otherCreated time.Duration
+		wantCreated  bool
+	}{
+		"other is older":   {otherCreated: -time.Minute, wantCreated: false},
+		"other is younger": {otherCreated: time.Minute, wantCreated: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			srv := cctest.NewServer()
+			defer srv.Close()
+			ccClient := srv.Client()
+			var other cc.Cluster
+			// The other cluster shows up between the lookup and the creation.
+			ccClient.Use(func(info cc.RequestInfo, req *http.Request, next cc.Next) (*http.Response, error) {
+				if info.Operation == "createCluster" {
+					other = srv.AddCluster(cc.Cluster{Name: "orders", Channel: srv.Params().Channels[0],
+						Generation: srv.Params().Channels[0].DefaultGeneration, ClusterPlantType: srv.Params().ClusterPlanTypes[0],
+						K8sContext: cc.K8sContext{UUID: srv.Params().Regions[0].Id, Name: srv.Params().Regions[0].Name},
+						Created:    srv.Now().Add(test.otherCreated)})
+				}
+				return next(req)
+			})
+
+			cluster, isNew, err := ccClient.EnsureCluster(context.Background(), cc.ClusterSpec{Name: "orders"})
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.wantCreated, isNew)
+			deletes := srv.RequestsTo("DELETE /clusters/{clusterId}")
+			if test.wantCreated {
+				// Deleting the other cluster is up to whoever created it.
+				assert.NotEqual(t, other.ID, cluster.ID)
+				assert.Empty(t, deletes)
+			} else {
+				assert.Equal(t, other.ID, cluster.ID)
+				if assert.Len(t, deletes, 1) {
+					assert.NotEqual(t, other.ID, deletes[0].Params["clusterId"])
+				}
+			}
+		})
+	}
+}
+
+func Test_EnsureCluster_noCreatedID(t *testing.T) {
+	srv := cctest.NewServer()
+	defer srv.Close()
+	ccClient := srv.Client()
+	srv.InjectFault("POST /clusters", cctest.Fault{Status: http.StatusOK, Body: "{}", Times: 1})
+
+	cluster, isNew, err := ccClient.EnsureCluster(context.Background(), cc.ClusterSpec{Name: "orders"})
+
+	assert.EqualError(t, err, "Camunda Cloud did not return the id of the created cluster orders")
+	assert.False(t, isNew)
+	assert.Empty(t, cluster.ID)
+}
+
+func Test_EnsureCluster_ignoresDeleting(t *testing.T) {
+	srv := cctest.NewServer()
+	defer srv.Close()
+	srv.DeletingPolls = 5
+	ccClient := srv.Client()
+	deleting := srv.AddCluster(cc.Cluster{Name: "orders", Created: srv.Now().Add(-time.Hour)})
+	_, err := ccClient.DeleteCluster(deleting.ID)
+	assert.NoError(t, err)
+
+	cluster, isNew, err := ccClient.EnsureCluster(context.Background(), cc.ClusterSpec{Name: "orders"})
+
+	assert.NoError(t, err)
+	assert.True(t, isNew, "the cluster being deleted does not count")
+	assert.NotEqual(t, deleting.ID, cluster.ID)
+	assert.Len(t, srv.RequestsTo("DELETE /clusters/{clusterId}"), 1, "the fresh cluster is kept although the deleting one is older")
+}
@@ -1,5 +1,13 @@
 package client
 
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
 func NewError(message string) error {
 	return &ErrorString{message}
 }
@@ -11,3 +19,48 @@ type ErrorString struct {
 func (e *ErrorString) Error() string {
 	return e.message
 }
+
+// HTTPError is returned when the Camunda Cloud API answers with a non 2xx status code.
+type HTTPError struct {
+	Operation  string
+	StatusCode int
+	Body       string
+}
+
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("HTTP Error trying to %s: %d", e.Operation, e.StatusCode)
+}
+
+// StatusError is returned by GetClustersWithStatus when the status of some clusters could not
+// be fetched. The status of these clusters is left empty.
+type StatusError struct {
+	// Errors has an error per cluster, in the order of the listing, prefixed with the cluster id.
+	Errors []error
+}
+
+func (e *StatusError) Error() string {
+	messages := []string{}
+	for _, err := range e.Errors {
+		messages = append(messages, err.Error())
+	}
+	return fmt.Sprintf("failed to get the status of %d clusters: %s", len(e.Errors), strings.Join(messages, "; "))
+}
+
+// Unwrap returns the first error, so that errors.Is and errors.As look at it.
+func (e *StatusError) Unwrap() error {
+	if len(e.Errors) == 0 {
+		return nil
+	}
+	return e.Errors[0]
+}
+
+// isTransient tells whether a request may succeed when sent again: it failed on the way,
+// or Camunda Cloud answered with 429 Too Many Requests or a 5xx status code.
+func isTransient(err error) bool {
+	httpErr := &HTTPError{}
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode == http.StatusTooManyRequests || httpErr.StatusCode >= 500
+	}
+	urlErr := &url.Error{}
+	return errors.As(err, &urlErr)
+}
@@ -0,0 +1,158 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+)
+
+// defaultDeleteConcurrency bounds the parallel deletions of DeleteClustersMatching.
+const defaultDeleteConcurrency = 4
+
+// GCOptions tells DeleteClustersMatching which of the matching clusters to delete, and how.
+type GCOptions struct {
+	// OlderThan only deletes clusters created longer ago. Zero deletes clusters of any age.
+	OlderThan time.Duration
+	// Exclude are glob patterns of cluster names never deleted, for example "ci-keep-*".
+	Exclude []string
+	// DryRun reports what would be deleted without deleting anything.
+	DryRun bool
+	// Concurrency is the number of parallel deletions, 4 by default.
+	Concurrency int
+	// Now is the clock the age of the clusters is measured with, time.Now by default.
+	Now func() time.Time
+}
+
+// GCEntry is a cluster of a GCReport.
+type GCEntry struct {
+	ClusterID string    `json:"clusterId"`
+	Name      string    `json:"name"`
+	Created   time.Time `json:"created"`
+	// Reason tells why a cluster was skipped.
+	Reason string `json:"reason,omitempty"`
+	// Error tells why the deletion of a cluster failed.
+	Error string `json:"error,omitempty"`
+}
+
+// GCReport lists what DeleteClustersMatching did with each cluster matching the filter,
+// in the order of the cluster listing.
+type GCReport struct {
+	// DryRun is set when Deleted lists the clusters that would have been deleted.
+	DryRun  bool      `json:"dryRun"`
+	Deleted []GCEntry `json:"deleted"`
+	Skipped []GCEntry `json:"skipped"`
+	Failed  []GCEntry `json:"failed"`
+}
+
+// Err returns an error summing the failed deletions up, nil when none failed.
+func (r GCReport) Err() error {
+	if len(r.Failed) == 0 {
+		return nil
+	}
+	failures := []string{}
+	for _, entry := range r.Failed {
+		failures = append(failures, entry.Name+": "+entry.Error)
+	}
+	return fmt.Errorf("failed to delete %d of %d clusters: %s",
+		len(r.Failed), len(r.Failed)+len(r.Deleted), strings.Join(failures, "; "))
+}
+
+// DeleteClustersMatching deletes the clusters matching the filter that are older than opts.OlderThan
+// and not excluded, a few at a time. Matching clusters that are too recent, excluded, of unknown age
+// or already deleting are reported as skipped. The returned error is about the options or listing
+// the clusters: failed deletions are in the report, see GCReport.Err.
+func (c *CCClient) DeleteClustersMatching(ctx context.Context, filter ListOptions, opts GCOptions) (GCReport, error) {
+
+	if c.tracingEnabled {
+		_, span := c.tracer.Start(ctx, "deleteClustersMatching")
+		defer span.End()
+	}
+
+	report := GCReport{DryRun: opts.DryRun, Deleted: []GCEntry{}, Skipped: []GCEntry{}, Failed: []GCEntry{}}
+
+	for _, pattern := range opts.Exclude {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return report, NewError("Invalid exclude glob: " + pattern)
+		}
+	}
+
+	clusters, err := c.ListClusters(ctx, filter)
+	if err != nil {
+		return report, err
+	}
+
+	now := time.Now
+	if opts.Now != nil {
+		now = opts.Now
+	}
+	cutoff := now().Add(-opts.OlderThan)
+
+	toDelete := []Cluster{}
+	for _, cluster := range clusters {
+		if reason := opts.skipReason(cluster, cutoff); reason != "" {
+			report.Skipped = append(report.Skipped, newGCEntry(cluster, reason, nil))
+			continue
+		}
+		toDelete = append(toDelete, cluster)
+	}
+
+	if opts.DryRun {
+		for _, cluster := range toDelete {
+			report.Deleted = append(report.Deleted, newGCEntry(cluster, "", nil))
+		}
+		return report, nil
+	}
+
+	errs := c.deleteClusters(ctx, toDelete, opts.Concurrency)
+	for i, cluster := range toDelete {
+		if errs[i] != nil {
+			report.Failed = append(report.Failed, newGCEntry(cluster, "", errs[i]))
+		} else {
+			report.Deleted = append(report.Deleted, newGCEntry(cluster, "", nil))
+		}
+	}
+	return report, nil
+}
+
+func (o GCOptions) skipReason(cluster Cluster, cutoff time.Time) string {
+	if cluster.Health() == HealthDeleting {
+		return "already deleting"
+	}
+	for _, pattern := range o.Exclude {
+		if matched, _ := path.Match(pattern, cluster.Name); matched {
+			return "excluded by " + pattern
+		}
+	}
+	if o.OlderThan > 0 {
+		if cluster.Created.IsZero() {
+			return "unknown creation time"
+		}
+		if cluster.Created.After(cutoff) {
+			return "created less than " + o.OlderThan.String() + " ago"
+		}
+	}
+	return ""
+}
+
+// deleteClusters deletes the clusters with a bounded pool of workers and returns the error of each.
+// Clusters not deleted yet when the context is done get its error.
+func (c *CCClient) deleteClusters(ctx context.Context, clusters []Cluster, concurrency int) []error {
+	if concurrency <= 0 {
+		concurrency = defaultDeleteConcurrency
+	}
+
+	return forEachIndex(ctx, len(clusters), concurrency, func(i int) error {
+		_, err := c.DeleteClusterWithContext(ctx, clusters[i].ID)
+		return err
+	})
+}
+
+func newGCEntry(cluster Cluster, reason string, err error) GCEntry {
+	entry := GCEntry{ClusterID: cluster.ID, Name: cluster.Name, Created: cluster.Created, Reason: reason}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+	return entry
+}
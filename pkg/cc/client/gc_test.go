@@ -0,0 +1,78 @@
+package client_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/camunda-community-hub/camunda-cloud-go-client/pkg/cc/cctest"
+	cc "github.com/camunda-community-hub/camunda-cloud-go-client/pkg/cc/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_DeleteClustersMatching(t *testing.T) {
+	srv := cctest.NewServer()
+	defer srv.Close()
+	now := time.Date(2021, 3, 5, 10, 0, 0, 0, time.UTC)
+	srv.Now = func() time.Time { return now }
+	ccClient := srv.Client()
+
+	old := now.Add(-48 * time.Hour)
+	first := srv.AddCluster(cc.Cluster{Name: "ci-1", Created: old})
+	srv.AddCluster(cc.Cluster{Name: "ci-keep", Created: old})
+	srv.AddCluster(cc.Cluster{Name: "ci-new", Created: now.Add(-time.Hour)})
+	second := srv.AddCluster(cc.Cluster{Name: "ci-2", Created: old})
+	srv.AddCluster(cc.Cluster{Name: "production", Created: old})
+	srv.InjectFault("DELETE /clusters/{clusterId}", cctest.Fail(500, 1))
+
+	filter := cc.ListOptions{Name: "ci-", NameMatch: cc.NameMatchPrefix}
+	opts := cc.GCOptions{
+		OlderThan:   24 * time.Hour,
+		Exclude:     []string{"*-keep"},
+		Concurrency: 1,
+		Now:         srv.Now,
+	}
+	report, err := ccClient.DeleteClustersMatching(context.Background(), filter, opts)
+
+	assert.NoError(t, err)
+	assert.False(t, report.DryRun)
+	assert.Equal(t, []cc.GCEntry{{ClusterID: second.ID, Name: "ci-2", Created: old}}, report.Deleted)
+	assert.Equal(t, []cc.GCEntry{
+		{ClusterID: report.Skipped[0].ClusterID, Name: "ci-keep", Created: old, Reason: "excluded by *-keep"},
+		{ClusterID: report.Skipped[1].ClusterID, Name: "ci-new", Created: now.Add(-time.Hour), Reason: "created less than 24h0m0s ago"},
+	}, report.Skipped)
+	if assert.Len(t, report.Failed, 1) {
+		assert.Equal(t, first.ID, report.Failed[0].ClusterID)
+		assert.Equal(t, "HTTP Error trying to deleteCluster: 500", report.Failed[0].Error)
+	}
+	assert.EqualError(t, report.Err(), "failed to delete 1 of 2 clusters: ci-1: HTTP Error trying to deleteCluster: 500")
+
+	report, err = ccClient.DeleteClustersMatching(context.Background(), filter, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, "already deleting", report.Skipped[2].Reason, "ci-2 is being deleted")
+}
+
+func Test_DeleteClustersMatching_dryRun(t *testing.T) {
+	srv := cctest.NewServer()
+	defer srv.Close()
+	ccClient := srv.Client()
+	cluster := srv.AddCluster(cc.Cluster{Name: "ci-1", Created: time.Now().Add(-48 * time.Hour)})
+
+	report, err := ccClient.DeleteClustersMatching(context.Background(), cc.ListOptions{}, cc.GCOptions{OlderThan: 24 * time.Hour, DryRun: true})
+
+	assert.NoError(t, err)
+	assert.True(t, report.DryRun)
+	assert.Len(t, report.Deleted, 1)
+	assert.Equal(t, cluster.ID, report.Deleted[0].ClusterID)
+	assert.Empty(t, srv.RequestsTo("DELETE /clusters/{clusterId}"))
+	assert.NoError(t, report.Err())
+}
+
+func Test_DeleteClustersMatching_invalidExclude(t *testing.T) {
+	srv := cctest.NewServer()
+	defer srv.Close()
+
+	_, err := srv.Client().DeleteClustersMatching(context.Background(), cc.ListOptions{}, cc.GCOptions{Exclude: []string{"["}})
+
+	assert.EqualError(t, err, "Invalid exclude glob: [")
+}
@@ -0,0 +1,233 @@
+package client
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// VersionGap is the most significant part of the version that differs between two generations.
+type VersionGap string
+
+const (
+	GapNone       VersionGap = "none"
+	GapMajor      VersionGap = "major"
+	GapMinor      VersionGap = "minor"
+	GapPatch      VersionGap = "patch"
+	GapPrerelease VersionGap = "prerelease"
+	// GapUnknown is reported when a generation name holds no version, or is not offered anymore.
+	GapUnknown VersionGap = "unknown"
+)
+
+// GenerationReport compares the generation of a cluster with the ones of its channel.
+type GenerationReport struct {
+	ClusterID string `json:"clusterId"`
+	Name      string `json:"name"`
+	Channel   string `json:"channel"`
+	// Generation is the current generation of the cluster.
+	Generation string `json:"generation"`
+	// Recommended is the default generation of the channel.
+	Recommended string `json:"recommended"`
+	// Latest is the newest generation allowed on the channel.
+	Latest string `json:"latest"`
+	// Gap is the version gap between the current and the recommended generation.
+	Gap VersionGap `json:"gap"`
+	// Behind is the number of generations allowed on the channel newer than the current one.
+	Behind int `json:"behind"`
+	// Since is when the cluster got its generation. The API does not tell when a cluster was
+	// upgraded, so this is the creation time of the cluster: the cluster may be newer on it.
+	Since time.Time `json:"since"`
+	Days  int       `json:"days"`
+	// Outdated is set when the current generation is older than the recommended one,
+	// or when it is not allowed on the channel anymore.
+	Outdated bool `json:"outdated"`
+}
+
+// NewGenerationReport compares the generation of the cluster with the default and newest generations
+// of its channel in the params. Generations are ordered by the version in their name, like "Zeebe 1.0.0".
+func NewGenerationReport(cluster Cluster, params ClusterParams, now time.Time) GenerationReport {
+	report := GenerationReport{
+		ClusterID:  cluster.ID,
+		Name:       cluster.Name,
+		Channel:    cluster.Channel.Name,
+		Generation: cluster.Generation.Name,
+		Gap:        GapUnknown,
+		Since:      cluster.Created,
+	}
+	if !cluster.Created.IsZero() && now.After(cluster.Created) {
+		report.Days = int(now.Sub(cluster.Created).Hours() / 24)
+	}
+
+	channel, found := findChannel(params, cluster.Channel)
+	if !found {
+		return report
+	}
+	report.Channel = channel.Name
+	report.Recommended = channel.DefaultGeneration.Name
+
+	if latest, found := latestGeneration(channel); found {
+		report.Latest = latest.Name
+	}
+
+	current, currentKnown := parseGenerationVersion(cluster.Generation.Name)
+	allowed := false
+	for _, generation := range channel.AllowedGeneration {
+		if sameGeneration(generation, cluster.Generation) {
+			allowed = true
+		}
+		if version, ok := parseGenerationVersion(generation.Name); ok && currentKnown && version.compare(current) > 0 {
+			report.Behind++
+		}
+	}
+
+	recommended, recommendedKnown := parseGenerationVersion(channel.DefaultGeneration.Name)
+	switch {
+	case currentKnown && recommendedKnown:
+		report.Gap = current.gap(recommended)
+		report.Outdated = current.compare(recommended) < 0
+	case cluster.Generation.Name == channel.DefaultGeneration.Name:
+		report.Gap = GapNone
+	}
+	if !allowed {
+		report.Outdated = true
+	}
+	return report
+}
+
+func findChannel(params ClusterParams, channel Channel) (Channel, bool) {
+	for _, candidate := range params.Channels {
+		if (channel.Id != "" && candidate.Id == channel.Id) || (channel.Id == "" && candidate.Name == channel.Name) {
+			return candidate, true
+		}
+	}
+	return Channel{}, false
+}
+
+// latestGeneration returns the allowed generation of the channel with the newest version.
+func latestGeneration(channel Channel) (Generation, bool) {
+	var latest Generation
+	var latestVersion generationVersion
+	found := false
+	for _, generation := range channel.AllowedGeneration {
+		version, ok := parseGenerationVersion(generation.Name)
+		if ok && (!found || version.compare(latestVersion) > 0) {
+			latest, latestVersion, found = generation, version, true
+		}
+	}
+	return latest, found
+}
+
+var versionPattern = regexp.MustCompile(`(\d+)\.(\d+)\.(\d+)(?:-([0-9A-Za-z.-]+))?`)
+
+// generationVersion is the semantic version in the name of a generation.
+type generationVersion struct {
+	major, minor, patch int
+	prerelease          string
+}
+
+func parseGenerationVersion(name string) (generationVersion, bool) {
+	match := versionPattern.FindStringSubmatch(name)
+	if match == nil {
+		return generationVersion{}, false
+	}
+	major, _ := strconv.Atoi(match[1])
+	minor, _ := strconv.Atoi(match[2])
+	patch, _ := strconv.Atoi(match[3])
+	return generationVersion{major: major, minor: minor, patch: patch, prerelease: match[4]}, true
+}
+
+// compare returns -1, 0 or 1 as v is older than, the same as or newer than other.
+// A prerelease is older than its release, prereleases are compared with comparePrerelease.
+func (v generationVersion) compare(other generationVersion) int {
+	for _, diff := range []int{v.major - other.major, v.minor - other.minor, v.patch - other.patch} {
+		if diff < 0 {
+			return -1
+		}
+		if diff > 0 {
+			return 1
+		}
+	}
+	switch {
+	case v.prerelease == other.prerelease:
+		return 0
+	case v.prerelease == "":
+		return 1
+	case other.prerelease == "":
+		return -1
+	}
+	return comparePrerelease(v.prerelease, other.prerelease)
+}
+
+var prereleasePartPattern = regexp.MustCompile(`\d+|\D+`)
+
+// comparePrerelease compares the dot-separated identifiers of two prereleases in turn, a prerelease
+// with more identifiers being newer when the others are the same. Within an identifier, numbers
+// are compared as numbers and are older than text, so that alpha2 is older than alpha10 and rc.2
+// older than rc.10.
+func comparePrerelease(a string, b string) int {
+	aIdentifiers, bIdentifiers := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(aIdentifiers) && i < len(bIdentifiers); i++ {
+		aParts := prereleasePartPattern.FindAllString(aIdentifiers[i], -1)
+		bParts := prereleasePartPattern.FindAllString(bIdentifiers[i], -1)
+		for j := 0; j < len(aParts) && j < len(bParts); j++ {
+			if c := comparePrereleasePart(aParts[j], bParts[j]); c != 0 {
+				return c
+			}
+		}
+		if c := compareInts(len(aParts), len(bParts)); c != 0 {
+			return c
+		}
+	}
+	return compareInts(len(aIdentifiers), len(bIdentifiers))
+}
+
+func comparePrereleasePart(a string, b string) int {
+	aNumber, bNumber := isDigits(a), isDigits(b)
+	switch {
+	case aNumber && bNumber:
+		// Compared without converting, numbers in names may not fit an int.
+		a, b = strings.TrimLeft(a, "0"), strings.TrimLeft(b, "0")
+		if c := compareInts(len(a), len(b)); c != 0 {
+			return c
+		}
+	case aNumber:
+		return -1
+	case bNumber:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+func compareInts(a int, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func (v generationVersion) gap(other generationVersion) VersionGap {
+	switch {
+	case v.major != other.major:
+		return GapMajor
+	case v.minor != other.minor:
+		return GapMinor
+	case v.patch != other.patch:
+		return GapPatch
+	case v.prerelease != other.prerelease:
+		return GapPrerelease
+	}
+	return GapNone
+}
@@ -0,0 +1,80 @@
+package client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewGenerationReport(t *testing.T) {
+	gen := func(name string) Generation { return Generation{Id: name, Name: name} }
+	stable := Channel{
+		Id:                "stable",
+		Name:              "Stable",
+		DefaultGeneration: gen("Zeebe 1.1.2"),
+		AllowedGeneration: []Generation{gen("Zeebe 1.0.0"), gen("Zeebe 1.1.2"), gen("Zeebe 1.2.0-alpha1"), gen("Zeebe 0.26.1")},
+	}
+	params := ClusterParams{Channels: []Channel{stable}}
+	created := time.Date(2021, 1, 1, 12, 0, 0, 0, time.UTC)
+	now := time.Date(2021, 3, 5, 10, 0, 0, 0, time.UTC)
+	cluster := func(generation string) Cluster {
+		return Cluster{ID: "id", Name: "orders", Channel: Channel{Id: "stable"}, Generation: gen(generation), Created: created}
+	}
+
+	report := NewGenerationReport(cluster("Zeebe 1.0.0"), params, now)
+	assert.Equal(t, GenerationReport{
+		ClusterID: "id", Name: "orders", Channel: "Stable",
+		Generation: "Zeebe 1.0.0", Recommended: "Zeebe 1.1.2", Latest: "Zeebe 1.2.0-alpha1",
+		Gap: GapMinor, Behind: 2, Since: created, Days: 62, Outdated: true,
+	}, report)
+
+	report = NewGenerationReport(cluster("Zeebe 1.1.2"), params, now)
+	assert.Equal(t, GapNone, report.Gap)
+	assert.Equal(t, 1, report.Behind)
+	assert.False(t, report.Outdated)
+
+	report = NewGenerationReport(cluster("Zeebe 1.2.0-alpha1"), params, now)
+	assert.Equal(t, GapMinor, report.Gap)
+	assert.False(t, report.Outdated)
+
+	report = NewGenerationReport(cluster("Zeebe 0.26.1"), params, now)
+	assert.Equal(t, GapMajor, report.Gap)
+	assert.Equal(t, 3, report.Behind)
+
+	report = NewGenerationReport(cluster("Zeebe 1.1.1"), params, now)
+	assert.Equal(t, GapPatch, report.Gap)
+	assert.True(t, report.Outdated, "generations not allowed anymore are outdated")
+
+	namesOnly := Channel{Id: "names", DefaultGeneration: Generation{Name: "Zeebe 1.1.2"}, AllowedGeneration: []Generation{{Name: "Zeebe 1.1.2"}}}
+	report = NewGenerationReport(Cluster{Channel: Channel{Id: "names"}, Generation: Generation{Name: "Zeebe 1.2.0"}},
+		ClusterParams{Channels: []Channel{namesOnly}}, now)
+	assert.True(t, report.Outdated, "generations without id are not matched by their empty id")
+
+	report = NewGenerationReport(Cluster{Channel: Channel{Id: "retired", Name: "Retired"}, Generation: gen("Zeebe 1.0.0")}, params, now)
+	assert.Equal(t, GenerationReport{Channel: "Retired", Generation: "Zeebe 1.0.0", Gap: GapUnknown}, report)
+}
+
+func Test_generationVersion_compare(t *testing.T) {
+	parse := func(name string) generationVersion {
+		v, ok := parseGenerationVersion(name)
+		assert.True(t, ok, name)
+		return v
+	}
+
+	assert.Equal(t, -1, parse("Zeebe 1.1.0-alpha1").compare(parse("Zeebe 1.1.0")))
+	assert.Equal(t, -1, parse("Zeebe 1.1.0-alpha1").compare(parse("Zeebe 1.1.0-alpha2")))
+	assert.Equal(t, 1, parse("Camunda 8.10.0").compare(parse("Camunda 8.9.3")))
+	assert.Equal(t, 0, parse("Zeebe 1.0.0").compare(parse("1.0.0")))
+	assert.Equal(t, GapPrerelease, parse("1.1.0-alpha1").gap(parse("1.1.0")))
+
+	assert.Equal(t, -1, parse("Zeebe 1.1.0-alpha2").compare(parse("Zeebe 1.1.0-alpha10")))
+	assert.Equal(t, 1, parse("Zeebe 1.1.0-rc.10").compare(parse("Zeebe 1.1.0-rc.2")))
+	assert.Equal(t, -1, parse("Zeebe 1.1.0-alpha10").compare(parse("Zeebe 1.1.0-beta1")))
+	assert.Equal(t, -1, parse("1.1.0-alpha").compare(parse("1.1.0-alpha.1")), "more identifiers are newer")
+	assert.Equal(t, -1, parse("1.1.0-1").compare(parse("1.1.0-alpha")), "numbers are older than text")
+	assert.Equal(t, 1, parse("1.1.0-rc.99999999999999999999").compare(parse("1.1.0-rc.9")))
+
+	_, ok := parseGenerationVersion("Zeebe latest")
+	assert.False(t, ok)
+}
@@ -0,0 +1,107 @@
+package client
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"log"
+	"net/http"
+	"time"
+)
+
+// RequestInfo tells interceptors which call of the client a request belongs to.
+type RequestInfo struct {
+	// Operation is the call, for example "createZeebeClient".
+	Operation string
+	// ClusterID is the cluster the call is about, when any.
+	ClusterID string
+	// ClientID is the Zeebe client the call is about, when any.
+	ClientID string
+}
+
+// Mutating tells whether the request changes something in Camunda Cloud. Logins are not mutating.
+func (info RequestInfo) Mutating(req *http.Request) bool {
+	return info.Operation != "login" && req.Method != http.MethodGet && req.Method != http.MethodHead
+}
+
+// Next sends the request on, to the next interceptor or to Camunda Cloud.
+type Next func(req *http.Request) (*http.Response, error)
+
+// Interceptor wraps every request of the client. It may change the request before calling
+// next, look at the response or error it returns, or answer without calling next at all.
+type Interceptor func(info RequestInfo, req *http.Request, next Next) (*http.Response, error)
+
+// Use appends interceptors to the chain. The first one used sees the requests first
+// and the responses last.
+func (c *CCClient) Use(interceptors ...Interceptor) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.interceptors = append(c.interceptors, interceptors...)
+}
+
+// send is the single way requests leave the client: through the interceptors, then the HTTP client.
+func (c *CCClient) send(info RequestInfo, req *http.Request) (*http.Response, error) {
+	c.mu.Lock()
+	interceptors := c.interceptors
+	c.mu.Unlock()
+
+	next := Next(c.getHTTPClient().Do)
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor, inner := interceptors[i], next
+		next = func(req *http.Request) (*http.Response, error) {
+			return interceptor(info, req, inner)
+		}
+	}
+	return next(req)
+}
+
+// UserAgent sets the User-Agent header of every request.
+func UserAgent(userAgent string) Interceptor {
+	return func(info RequestInfo, req *http.Request, next Next) (*http.Response, error) {
+		req.Header.Set("User-Agent", userAgent)
+		return next(req)
+	}
+}
+
+// RequestIDHeader carries the id RequestID gives to every request.
+const RequestIDHeader = "X-Request-ID"
+
+// RequestID gives every request a random id in the X-Request-ID header, unless it already has one,
+// so that a failed call can be found in the logs of both sides.
+func RequestID() Interceptor {
+	return func(info RequestInfo, req *http.Request, next Next) (*http.Response, error) {
+		if req.Header.Get(RequestIDHeader) == "" {
+			req.Header.Set(RequestIDHeader, newRequestID())
+		}
+		return next(req)
+	}
+}
+
+func newRequestID() string {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(id)
+}
+
+// TimingFunc receives how long a request took, with its response or error.
+type TimingFunc func(info RequestInfo, req *http.Request, resp *http.Response, err error, elapsed time.Duration)
+
+// Timing measures every request, until the response headers are received.
+func Timing(observe TimingFunc) Interceptor {
+	return func(info RequestInfo, req *http.Request, next Next) (*http.Response, error) {
+		start := time.Now()
+		resp, err := next(req)
+		observe(info, req, resp, err, time.Since(start))
+		return resp, err
+	}
+}
+
+// LogTiming is a TimingFunc that logs the requests.
+func LogTiming(info RequestInfo, req *http.Request, resp *http.Response, err error, elapsed time.Duration) {
+	if err != nil {
+		log.Printf("%s %s %s failed after %s: %v", info.Operation, req.Method, req.URL.Path, elapsed, err)
+		return
+	}
+	log.Printf("%s %s %s %d in %s", info.Operation, req.Method, req.URL.Path, resp.StatusCode, elapsed)
+}
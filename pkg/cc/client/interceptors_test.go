@@ -0,0 +1,101 @@
+package client_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/camunda-community-hub/camunda-cloud-go-client/pkg/cc/cctest"
+	cc "github.com/camunda-community-hub/camunda-cloud-go-client/pkg/cc/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Interceptors(t *testing.T) {
+	srv := cctest.NewServer()
+	defer srv.Close()
+	ccClient := srv.Client()
+	cluster := srv.AddCluster(cc.Cluster{Name: "orders"})
+
+	calls := []string{}
+	mutating := []cc.RequestInfo{}
+	timed := []string{}
+	ccClient.Use(
+		cc.UserAgent("cc-test"),
+		cc.RequestID(),
+		func(info cc.RequestInfo, req *http.Request, next cc.Next) (*http.Response, error) {
+			calls = append(calls, "outer "+info.Operation)
+			return next(req)
+		},
+		func(info cc.RequestInfo, req *http.Request, next cc.Next) (*http.Response, error) {
+			calls = append(calls, "inner "+info.Operation)
+			if info.Mutating(req) {
+				mutating = append(mutating, info)
+			}
+			return next(req)
+		},
+		cc.Timing(func(info cc.RequestInfo, req *http.Request, resp *http.Response, err error, elapsed time.Duration) {
+			timed = append(timed, info.Operation+" "+http.StatusText(resp.StatusCode))
+		}),
+	)
+
+	created, err := ccClient.CreateZeebeClient(cluster.ID, "worker")
+	assert.NoError(t, err)
+	_, err = ccClient.GetMembers()
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"outer createZeebeClient", "inner createZeebeClient", "outer getMembers", "inner getMembers"}, calls)
+	assert.Equal(t, []cc.RequestInfo{{Operation: "createZeebeClient", ClusterID: cluster.ID}}, mutating)
+	assert.Equal(t, []string{"createZeebeClient OK", "getMembers OK"}, timed)
+	assert.NotEmpty(t, created.ClientID)
+
+	requests := srv.RequestsTo("POST /clusters/{clusterId}/clients")
+	if assert.Len(t, requests, 1) {
+		assert.Equal(t, "cc-test", requests[0].Header.Get("User-Agent"))
+		assert.Len(t, requests[0].Header.Get(cc.RequestIDHeader), 32)
+	}
+}
+
+func Test_Interceptors_shortCircuit(t *testing.T) {
+	srv := cctest.NewServer()
+	defer srv.Close()
+	ccClient := srv.Client()
+	sent := len(srv.Requests())
+
+	readOnly := errors.New("read only")
+	ccClient.Use(func(info cc.RequestInfo, req *http.Request, next cc.Next) (*http.Response, error) {
+		if info.Mutating(req) {
+			return nil, readOnly
+		}
+		return next(req)
+	})
+
+	_, err := ccClient.DeleteMember("someone@example.com")
+	assert.True(t, errors.Is(err, readOnly))
+	_, err = ccClient.GetClusters()
+	assert.NoError(t, err)
+	assert.Len(t, srv.Requests(), sent+1)
+}
+
+func Test_CopySettings(t *testing.T) {
+	srv := cctest.NewServer()
+	defer srv.Close()
+	ccClient := &cc.CCClient{}
+	ccClient.Use(cc.UserAgent("cc-test"))
+
+	copied := ccClient.CopySettings()
+	srv.Configure(copied)
+	copied.Use(cc.RequestID())
+	_, err := copied.Login("cctest", "cctest")
+	assert.NoError(t, err)
+
+	request := srv.Requests()[0]
+	assert.Equal(t, "cc-test", request.Header.Get("User-Agent"))
+	assert.NotEmpty(t, request.Header.Get(cc.RequestIDHeader))
+
+	srv.Configure(ccClient)
+	srv.ResetRequests()
+	_, err = ccClient.Login("cctest", "cctest")
+	assert.NoError(t, err)
+	assert.Empty(t, srv.Requests()[0].Header.Get(cc.RequestIDHeader), "interceptors used on the copy only")
+}
@@ -0,0 +1,295 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	defaultInventoryOldest      = 5
+	defaultInventoryConcurrency = 4
+)
+
+// InventoryField is a field clusters are grouped by in an InventoryReport.
+type InventoryField string
+
+const (
+	InventoryPlan       InventoryField = "plan"
+	InventoryRegion     InventoryField = "region"
+	InventoryChannel    InventoryField = "channel"
+	InventoryGeneration InventoryField = "generation"
+)
+
+// InventoryFields are the fields clusters can be grouped by.
+var InventoryFields = []InventoryField{InventoryPlan, InventoryRegion, InventoryChannel, InventoryGeneration}
+
+// ParseInventoryFields returns the fields named, see InventoryFields. Empty names are ignored.
+func ParseInventoryFields(names []string) ([]InventoryField, error) {
+	fields := []InventoryField{}
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		field, ok := findInventoryField(name)
+		if !ok {
+			return nil, NewError("Unknown inventory field: " + name)
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+func findInventoryField(name string) (InventoryField, bool) {
+	for _, field := range InventoryFields {
+		if strings.EqualFold(string(field), name) {
+			return field, true
+		}
+	}
+	return "", false
+}
+
+// InventoryCluster is a cluster of an InventoryReport, with the names of its plan, region, channel and generation.
+type InventoryCluster struct {
+	ClusterID  string    `json:"clusterId"`
+	Name       string    `json:"name"`
+	Plan       string    `json:"plan"`
+	Region     string    `json:"region"`
+	Channel    string    `json:"channel"`
+	Generation string    `json:"generation"`
+	Created    time.Time `json:"created"`
+	// ZeebeClients is the number of Zeebe clients of the cluster, internal ones excluded.
+	ZeebeClients int `json:"zeebeClients"`
+	// Owners are the users who created the Zeebe clients of the cluster. The API does not
+	// tell who created a cluster, so clusters without Zeebe clients have no owners.
+	Owners []string `json:"owners"`
+}
+
+// Field returns the value of a field clusters are grouped by.
+func (c InventoryCluster) Field(field InventoryField) string {
+	switch field {
+	case InventoryPlan:
+		return c.Plan
+	case InventoryRegion:
+		return c.Region
+	case InventoryChannel:
+		return c.Channel
+	case InventoryGeneration:
+		return c.Generation
+	}
+	return ""
+}
+
+// InventoryGroup sums up the clusters sharing the same values of the fields grouped by.
+type InventoryGroup struct {
+	// Values are the values of the fields grouped by, in the order of InventoryReport.GroupBy.
+	Values              []string `json:"values"`
+	Clusters            int      `json:"clusters"`
+	ZeebeClients        int      `json:"zeebeClients"`
+	WithoutZeebeClients int      `json:"withoutZeebeClients"`
+	// Oldest is the name of the oldest cluster of the group.
+	Oldest        string    `json:"oldest"`
+	OldestCreated time.Time `json:"oldestCreated"`
+	Owners        []string  `json:"owners"`
+}
+
+// InventoryReport counts the clusters of an organization per group, see Inventory.
+type InventoryReport struct {
+	GroupBy []InventoryField `json:"groupBy"`
+	// Groups are sorted by their values.
+	Groups []InventoryGroup `json:"groups"`
+	// Total sums up all the clusters, it has no values.
+	Total InventoryGroup `json:"total"`
+	// Oldest are the oldest clusters, oldest first.
+	Oldest []InventoryCluster `json:"oldest"`
+	// WithoutZeebeClients are the clusters without any Zeebe client, sorted by name.
+	WithoutZeebeClients []InventoryCluster `json:"withoutZeebeClients"`
+	// Clusters are all the clusters, sorted by name.
+	Clusters []InventoryCluster `json:"clusters"`
+}
+
+// InventoryOptions tells Inventory how to group the clusters.
+type InventoryOptions struct {
+	// GroupBy are the fields clusters are grouped by, no field gives a single group.
+	GroupBy []InventoryField
+	// Oldest is the number of oldest clusters reported, 5 by default.
+	Oldest int
+	// Concurrency is the number of parallel Zeebe client requests, 4 by default.
+	Concurrency int
+}
+
+// Inventory counts the clusters matching the filter per group, with their Zeebe clients. The names of the
+// plans, regions, channels and generations missing from the clusters are looked up in the cluster params.
+func (c *CCClient) Inventory(ctx context.Context, filter ListOptions, opts InventoryOptions) (InventoryReport, error) {
+
+	if c.tracingEnabled {
+		_, span := c.tracer.Start(ctx, "inventory")
+		defer span.End()
+	}
+
+	if opts.Oldest <= 0 {
+		opts.Oldest = defaultInventoryOldest
+	}
+
+	report := InventoryReport{
+		GroupBy:             opts.GroupBy,
+		Groups:              []InventoryGroup{},
+		Total:               InventoryGroup{Values: []string{}, Owners: []string{}},
+		Oldest:              []InventoryCluster{},
+		WithoutZeebeClients: []InventoryCluster{},
+		Clusters:            []InventoryCluster{},
+	}
+	if report.GroupBy == nil {
+		report.GroupBy = []InventoryField{}
+	}
+
+	params, err := c.GetClusterParamsWithContext(ctx)
+	if err != nil {
+		return report, err
+	}
+	filter.SortBy, filter.Descending = SortByName, false
+	clusters, err := c.ListClusters(ctx, filter)
+	if err != nil {
+		return report, err
+	}
+	clients, err := c.zeebeClientsOf(ctx, clusters, opts.Concurrency)
+	if err != nil {
+		return report, err
+	}
+
+	groups := map[string]*InventoryGroup{}
+	for i, cluster := range clusters {
+		item := newInventoryCluster(cluster, *params, clients[i])
+		report.Clusters = append(report.Clusters, item)
+		if item.ZeebeClients == 0 {
+			report.WithoutZeebeClients = append(report.WithoutZeebeClients, item)
+		}
+
+		values := []string{}
+		for _, field := range report.GroupBy {
+			values = append(values, item.Field(field))
+		}
+		key := strings.Join(values, "\x00")
+		if groups[key] == nil {
+			groups[key] = &InventoryGroup{Values: values, Owners: []string{}}
+		}
+		groups[key].add(item)
+		report.Total.add(item)
+	}
+
+	for _, group := range groups {
+		report.Groups = append(report.Groups, *group)
+	}
+	sort.Slice(report.Groups, func(i, j int) bool {
+		return strings.Join(report.Groups[i].Values, "\x00") < strings.Join(report.Groups[j].Values, "\x00")
+	})
+
+	report.Oldest = append(report.Oldest, report.Clusters...)
+	sort.SliceStable(report.Oldest, func(i, j int) bool { return report.Oldest[i].Created.Before(report.Oldest[j].Created) })
+	if len(report.Oldest) > opts.Oldest {
+		report.Oldest = report.Oldest[:opts.Oldest]
+	}
+	return report, nil
+}
+
+func (g *InventoryGroup) add(cluster InventoryCluster) {
+	g.Clusters++
+	g.ZeebeClients += cluster.ZeebeClients
+	if cluster.ZeebeClients == 0 {
+		g.WithoutZeebeClients++
+	}
+	if g.Oldest == "" || cluster.Created.Before(g.OldestCreated) {
+		g.Oldest, g.OldestCreated = cluster.Name, cluster.Created
+	}
+	g.Owners = mergeOwners(g.Owners, cluster.Owners)
+}
+
+func newInventoryCluster(cluster Cluster, params ClusterParams, clients []ZeebeClientResponse) InventoryCluster {
+	cluster = resolveClusterNames(cluster, params)
+	item := InventoryCluster{
+		ClusterID:  cluster.ID,
+		Name:       cluster.Name,
+		Plan:       cluster.ClusterPlantType.Name,
+		Region:     cluster.K8sContext.Name,
+		Channel:    cluster.Channel.Name,
+		Generation: cluster.Generation.Name,
+		Created:    cluster.Created,
+		Owners:     []string{},
+	}
+
+	for _, client := range clients {
+		if client.Internal {
+			continue
+		}
+		item.ZeebeClients++
+		if client.CreatedBy != "" {
+			item.Owners = mergeOwners(item.Owners, []string{client.CreatedBy})
+		}
+	}
+	return item
+}
+
+// resolveClusterNames fills the names of the plan, region, channel and generation of the cluster
+// that the listing left out from the parameters of the organization.
+func resolveClusterNames(cluster Cluster, params ClusterParams) Cluster {
+	for _, plan := range params.ClusterPlanTypes {
+		if cluster.ClusterPlantType.Name == "" && plan.Id == cluster.ClusterPlantType.Id {
+			cluster.ClusterPlantType.Name = plan.Name
+		}
+	}
+	for _, region := range params.Regions {
+		if cluster.K8sContext.Name == "" && region.Id == cluster.K8sContext.UUID {
+			cluster.K8sContext.Name = region.Name
+		}
+	}
+	for _, channel := range params.Channels {
+		if cluster.Channel.Name == "" && channel.Id == cluster.Channel.Id {
+			cluster.Channel.Name = channel.Name
+		}
+		for _, generation := range channel.AllowedGeneration {
+			if cluster.Generation.Name == "" && generation.Id == cluster.Generation.Id {
+				cluster.Generation.Name = generation.Name
+			}
+		}
+	}
+	return cluster
+}
+
+// mergeOwners returns the sorted union of the owners.
+func mergeOwners(owners []string, more []string) []string {
+	for _, owner := range more {
+		i := sort.SearchStrings(owners, owner)
+		if i < len(owners) && owners[i] == owner {
+			continue
+		}
+		owners = append(owners, "")
+		copy(owners[i+1:], owners[i:])
+		owners[i] = owner
+	}
+	return owners
+}
+
+// zeebeClientsOf lists the Zeebe clients of each cluster with a bounded pool of workers.
+// It returns the first error, once the running requests are over.
+func (c *CCClient) zeebeClientsOf(ctx context.Context, clusters []Cluster, concurrency int) ([][]ZeebeClientResponse, error) {
+	if concurrency <= 0 {
+		concurrency = defaultInventoryConcurrency
+	}
+
+	clients := make([][]ZeebeClientResponse, len(clusters))
+	errs := forEachIndex(ctx, len(clusters), concurrency, func(i int) error {
+		var err error
+		clients[i], err = c.GetZeebeClientsWithContext(ctx, clusters[i].ID)
+		return err
+	})
+
+	for i, err := range errs {
+		if err != nil {
+			return nil, fmt.Errorf("cannot list the Zeebe clients of cluster %s: %v", clusters[i].Name, err)
+		}
+	}
+	return clients, nil
+}
@@ -0,0 +1,97 @@
+package client_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/camunda-community-hub/camunda-cloud-go-client/pkg/cc/cctest"
+	cc "github.com/camunda-community-hub/camunda-cloud-go-client/pkg/cc/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Inventory(t *testing.T) {
+	srv := cctest.NewServer()
+	defer srv.Close()
+	ccClient := srv.Client()
+	params := srv.Params()
+	development, production := params.ClusterPlanTypes[0], params.ClusterPlanTypes[1]
+	europe, us := cc.K8sContext{UUID: params.Regions[0].Id, Name: params.Regions[0].Name}, cc.K8sContext{UUID: params.Regions[1].Id}
+	day := func(d int) time.Time { return time.Date(2021, 3, d, 0, 0, 0, 0, time.UTC) }
+
+	orders := srv.AddCluster(cc.Cluster{Name: "orders", ClusterPlantType: production, K8sContext: europe, Created: day(3)})
+	payments := srv.AddCluster(cc.Cluster{Name: "payments", ClusterPlantType: production, K8sContext: europe, Created: day(1)})
+	srv.AddCluster(cc.Cluster{Name: "sandbox", ClusterPlantType: cc.ClusterPlantType{Id: development.Id}, K8sContext: us, Created: day(2)})
+	srv.CreatedBy = "alice"
+	srv.AddZeebeClient(orders.ID, "worker")
+	srv.AddZeebeClient(payments.ID, "worker")
+	srv.CreatedBy = "bob"
+	srv.AddZeebeClient(orders.ID, "starter")
+
+	report, err := ccClient.Inventory(context.Background(), cc.ListOptions{},
+		cc.InventoryOptions{GroupBy: []cc.InventoryField{cc.InventoryPlan, cc.InventoryRegion}, Oldest: 2})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []cc.InventoryGroup{
+		{Values: []string{"Development", "US East"}, Clusters: 1, WithoutZeebeClients: 1, Oldest: "sandbox", OldestCreated: day(2), Owners: []string{}},
+		{Values: []string{"Production - S", "Europe West"}, Clusters: 2, ZeebeClients: 3, Oldest: "payments", OldestCreated: day(1), Owners: []string{"alice", "bob"}},
+	}, report.Groups, "names missing from the clusters come from the params")
+	assert.Equal(t, cc.InventoryGroup{Values: []string{}, Clusters: 3, ZeebeClients: 3, WithoutZeebeClients: 1,
+		Oldest: "payments", OldestCreated: day(1), Owners: []string{"alice", "bob"}}, report.Total)
+	assert.Equal(t, []string{"payments", "sandbox"}, []string{report.Oldest[0].Name, report.Oldest[1].Name})
+	if assert.Len(t, report.WithoutZeebeClients, 1) {
+		assert.Equal(t, "sandbox", report.WithoutZeebeClients[0].Name)
+	}
+	assert.Equal(t, []string{"alice", "bob"}, report.Clusters[0].Owners)
+}
+
+func Test_Inventory_zeebeClientsFail(t *testing.T) {
+	srv := cctest.NewServer()
+	defer srv.Close()
+	ccClient := srv.Client()
+	srv.AddCluster(cc.Cluster{Name: "orders"})
+	srv.InjectFault("GET /clusters/{clusterId}/clients", cctest.Fail(500, 1))
+
+	_, err := ccClient.Inventory(context.Background(), cc.ListOptions{}, cc.InventoryOptions{})
+
+	assert.EqualError(t, err, "cannot list the Zeebe clients of cluster orders: HTTP Error trying to getZeebeClients: 500")
+}
+
+func Test_ParseInventoryFields(t *testing.T) {
+	fields, err := cc.ParseInventoryFields([]string{"Plan", " region", ""})
+	assert.NoError(t, err)
+	assert.Equal(t, []cc.InventoryField{cc.InventoryPlan, cc.InventoryRegion}, fields)
+
+	_, err = cc.ParseInventoryFields([]string{"owner"})
+	assert.EqualError(t, err, "Unknown inventory field: owner")
+}
+
+func Test_SnapshotOrganization_resolvesMissingNames(t *testing.T) {
+	dev, prod := cctest.NewServer(), cctest.NewServer()
+	defer dev.Close()
+	defer prod.Close()
+	params := dev.Params()
+	stable := params.Channels[0]
+	idsOnly := func(generation cc.Generation, plan cc.ClusterPlantType) cc.Cluster {
+		return cc.Cluster{Name: "orders", Channel: cc.Channel{Id: stable.Id}, Generation: cc.Generation{Id: generation.Id},
+			ClusterPlantType: cc.ClusterPlantType{Id: plan.Id}, K8sContext: cc.K8sContext{UUID: params.Regions[0].Id}}
+	}
+	dev.AddCluster(idsOnly(stable.AllowedGeneration[1], params.ClusterPlanTypes[0]))
+	prod.AddCluster(idsOnly(stable.AllowedGeneration[0], params.ClusterPlanTypes[1]))
+
+	devSnapshot, err := dev.Client().SnapshotOrganization(context.Background(), "dev")
+	assert.NoError(t, err)
+	prodSnapshot, err := prod.Client().SnapshotOrganization(context.Background(), "prod")
+	assert.NoError(t, err)
+
+	cluster := devSnapshot.Clusters[0].Cluster
+	assert.Equal(t, []string{"Stable", "Zeebe 1.0.0", "Development", "Europe West"},
+		[]string{cluster.Channel.Name, cluster.Generation.Name, cluster.ClusterPlantType.Name, cluster.K8sContext.Name})
+
+	report, err := cc.CompareOrganizations([]cc.OrganizationSnapshot{devSnapshot, prodSnapshot}, cc.CompareOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, []cc.Difference{
+		{Cluster: "orders", What: "generation", Values: []string{"Zeebe 1.0.0", "Zeebe 0.26.1"}},
+		{Cluster: "orders", What: "plan", Values: []string{"Development", "Production - S"}},
+	}, report.Differences)
+}
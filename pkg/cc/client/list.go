@@ -0,0 +1,203 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ErrAmbiguousName is returned when a cluster name lookup matches more than one cluster.
+var ErrAmbiguousName = errors.New("cluster name matches more than one cluster")
+
+// ErrClusterExists is returned when creating a cluster with the name of an existing one.
+var ErrClusterExists = errors.New("Cluster name already exists on Camunda Cloud")
+
+// NameMatch defines how ListOptions.Name is compared with cluster names.
+type NameMatch string
+
+const (
+	NameMatchExact  NameMatch = "exact"
+	NameMatchPrefix NameMatch = "prefix"
+	NameMatchGlob   NameMatch = "glob"
+	NameMatchRegex  NameMatch = "regex"
+)
+
+// ClusterSortField is the cluster attribute ListClusters sorts by.
+type ClusterSortField string
+
+const (
+	SortByName       ClusterSortField = "name"
+	SortByCreated    ClusterSortField = "created"
+	SortByGeneration ClusterSortField = "generation"
+	SortByPlan       ClusterSortField = "plan"
+	SortByRegion     ClusterSortField = "region"
+)
+
+// ListOptions filters, sorts and limits the clusters returned by ListClusters.
+// Channel, Generation, Plan and Region match either the id or the name (case insensitive).
+// Zero values disable the corresponding filter.
+type ListOptions struct {
+	Name          string
+	NameMatch     NameMatch
+	Channel       string
+	Generation    string
+	Plan          string
+	Region        string
+	Ready         *bool
+	CreatedBefore time.Time
+	CreatedAfter  time.Time
+	SortBy        ClusterSortField
+	Descending    bool
+	Limit         int
+}
+
+// ListClusters returns the clusters matching the options.
+// When filtering by readiness the missing cluster status is fetched as in GetClustersWithStatus.
+func (c *CCClient) ListClusters(ctx context.Context, opts ListOptions) ([]Cluster, error) {
+
+	if c.tracingEnabled {
+		_, span := c.tracer.Start(ctx, "listClusters")
+		defer span.End()
+	}
+
+	var clusters []Cluster
+	var err error
+
+	if opts.Ready != nil {
+		clusters, err = c.GetClustersWithStatus(ctx)
+	} else {
+		clusters, err = c.GetClustersWithContext(ctx)
+	}
+
+	if err != nil {
+		return []Cluster{}, err
+	}
+
+	return FilterClusters(clusters, opts)
+}
+
+// FilterClusters applies the options to an already fetched list of clusters.
+func FilterClusters(clusters []Cluster, opts ListOptions) ([]Cluster, error) {
+	matchName, err := opts.nameMatcher()
+
+	if err != nil {
+		return []Cluster{}, err
+	}
+
+	filtered := []Cluster{}
+	for _, cluster := range clusters {
+		if matchName(cluster.Name) && opts.matches(cluster) {
+			filtered = append(filtered, cluster)
+		}
+	}
+
+	if opts.SortBy != "" {
+		less, err := sortLess(opts.SortBy)
+		if err != nil {
+			return []Cluster{}, err
+		}
+		sort.SliceStable(filtered, func(i, j int) bool {
+			if opts.Descending {
+				return less(filtered[j], filtered[i])
+			}
+			return less(filtered[i], filtered[j])
+		})
+	}
+
+	if opts.Limit > 0 && len(filtered) > opts.Limit {
+		filtered = filtered[:opts.Limit]
+	}
+
+	return filtered, nil
+}
+
+func (o ListOptions) nameMatcher() (func(string) bool, error) {
+	if o.Name == "" {
+		return func(string) bool { return true }, nil
+	}
+
+	switch o.NameMatch {
+	case "", NameMatchExact:
+		return func(name string) bool { return name == o.Name }, nil
+	case NameMatchPrefix:
+		return func(name string) bool { return strings.HasPrefix(name, o.Name) }, nil
+	case NameMatchGlob:
+		if _, err := path.Match(o.Name, ""); err != nil {
+			return nil, NewError("Invalid name glob: " + o.Name)
+		}
+		return func(name string) bool {
+			matched, _ := path.Match(o.Name, name)
+			return matched
+		}, nil
+	case NameMatchRegex:
+		re, err := regexp.Compile(o.Name)
+		if err != nil {
+			return nil, NewError("Invalid name regex: " + err.Error())
+		}
+		return re.MatchString, nil
+	}
+
+	return nil, NewError("Unknown name match: " + string(o.NameMatch))
+}
+
+func (o ListOptions) matches(cluster Cluster) bool {
+	if !matchesIdOrName(o.Channel, cluster.Channel.Id, cluster.Channel.Name) {
+		return false
+	}
+	if !matchesIdOrName(o.Generation, cluster.Generation.Id, cluster.Generation.Name) {
+		return false
+	}
+	if !matchesIdOrName(o.Plan, cluster.ClusterPlantType.Id, cluster.ClusterPlantType.Name) {
+		return false
+	}
+	if !matchesIdOrName(o.Region, cluster.K8sContext.UUID, cluster.K8sContext.Name) {
+		return false
+	}
+	if o.Ready != nil && *o.Ready != cluster.Status.IsReady() {
+		return false
+	}
+	if !o.CreatedBefore.IsZero() && !cluster.Created.Before(o.CreatedBefore) {
+		return false
+	}
+	if !o.CreatedAfter.IsZero() && !cluster.Created.After(o.CreatedAfter) {
+		return false
+	}
+	return true
+}
+
+func matchesIdOrName(filter string, id string, name string) bool {
+	return filter == "" || filter == id || strings.EqualFold(filter, name)
+}
+
+// generationLess orders generations by version, so that Zeebe 1.10.0 comes after Zeebe 1.9.0,
+// and by name when a version cannot be parsed or the versions are the same.
+func generationLess(a string, b string) bool {
+	versionA, okA := parseGenerationVersion(a)
+	versionB, okB := parseGenerationVersion(b)
+	if okA && okB {
+		if order := versionA.compare(versionB); order != 0 {
+			return order < 0
+		}
+	}
+	return a < b
+}
+
+func sortLess(field ClusterSortField) (func(a Cluster, b Cluster) bool, error) {
+	switch field {
+	case SortByName:
+		return func(a Cluster, b Cluster) bool { return a.Name < b.Name }, nil
+	case SortByCreated:
+		return func(a Cluster, b Cluster) bool { return a.Created.Before(b.Created) }, nil
+	case SortByGeneration:
+		return func(a Cluster, b Cluster) bool { return generationLess(a.Generation.Name, b.Generation.Name) }, nil
+	case SortByPlan:
+		return func(a Cluster, b Cluster) bool { return a.ClusterPlantType.Name < b.ClusterPlantType.Name }, nil
+	case SortByRegion:
+		return func(a Cluster, b Cluster) bool { return a.K8sContext.Name < b.K8sContext.Name }, nil
+	}
+	return nil, NewError("Unknown sort field: " + string(field))
+}
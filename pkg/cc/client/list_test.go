@@ -0,0 +1,83 @@
+package client
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testClusters() []Cluster {
+	return []Cluster{
+		{ID: "1", Name: "ci-build-1", Created: time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC), Channel: Channel{Id: "stable", Name: "Stable"},
+			ClusterPlantType: ClusterPlantType{Id: "dev", Name: "Development"}, Status: ClusterStatus{Ready: "Healthy"}},
+		{ID: "2", Name: "prod-eu", Created: time.Date(2021, 1, 1, 10, 0, 0, 0, time.UTC), Channel: Channel{Id: "stable", Name: "Stable"},
+			ClusterPlantType: ClusterPlantType{Id: "prod", Name: "Production"}, Status: ClusterStatus{Ready: "Unhealthy"}},
+		{ID: "3", Name: "ci-build-2", Created: time.Date(2021, 2, 1, 10, 0, 0, 0, time.UTC), Channel: Channel{Id: "alpha", Name: "Alpha"},
+			ClusterPlantType: ClusterPlantType{Id: "dev", Name: "Development"}, Status: ClusterStatus{Ready: "Healthy"}},
+	}
+}
+
+func clusterIDs(clusters []Cluster) []string {
+	ids := []string{}
+	for _, c := range clusters {
+		ids = append(ids, c.ID)
+	}
+	return ids
+}
+
+func Test_FilterClusters_names(t *testing.T) {
+	tests := []struct {
+		opts ListOptions
+		want []string
+	}{
+		{ListOptions{Name: "prod-eu"}, []string{"2"}},
+		{ListOptions{Name: "ci-", NameMatch: NameMatchPrefix}, []string{"1", "3"}},
+		{ListOptions{Name: "ci-*-2", NameMatch: NameMatchGlob}, []string{"3"}},
+		{ListOptions{Name: "^(prod|ci-build-1)", NameMatch: NameMatchRegex}, []string{"1", "2"}},
+	}
+	for _, tt := range tests {
+		clusters, err := FilterClusters(testClusters(), tt.opts)
+		assert.NoError(t, err)
+		assert.Equal(t, tt.want, clusterIDs(clusters), "%+v", tt.opts)
+	}
+
+	_, err := FilterClusters(testClusters(), ListOptions{Name: "(", NameMatch: NameMatchRegex})
+	assert.Error(t, err)
+}
+
+func Test_FilterClusters_attributes(t *testing.T) {
+	ready := true
+	clusters, err := FilterClusters(testClusters(), ListOptions{Channel: "stable", Plan: "development", Ready: &ready})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1"}, clusterIDs(clusters))
+
+	clusters, err = FilterClusters(testClusters(), ListOptions{
+		CreatedAfter:  time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC),
+		CreatedBefore: time.Date(2021, 2, 15, 0, 0, 0, 0, time.UTC),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"3"}, clusterIDs(clusters))
+}
+
+func Test_FilterClusters_sortAndLimit(t *testing.T) {
+	clusters, err := FilterClusters(testClusters(), ListOptions{SortBy: SortByCreated})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"2", "3", "1"}, clusterIDs(clusters))
+
+	clusters, err = FilterClusters(testClusters(), ListOptions{SortBy: SortByName, Descending: true, Limit: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"2", "3"}, clusterIDs(clusters))
+
+	generations := []Cluster{}
+	for i, name := range []string{"Zeebe 1.10.0", "Zeebe 1.9.0", "Zeebe latest", "Zeebe 1.10.0-alpha1", "Zeebe 0.26.1"} {
+		generations = append(generations, Cluster{ID: strconv.Itoa(i), Generation: Generation{Name: name}})
+	}
+	clusters, err = FilterClusters(generations, ListOptions{SortBy: SortByGeneration})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"4", "1", "3", "0", "2"}, clusterIDs(clusters))
+
+	_, err = FilterClusters(testClusters(), ListOptions{SortBy: "size"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,104 @@
+package client
+
+import (
+	"context"
+	"net/url"
+)
+
+func (c *CCClient) GetMembers() ([]OrganizationMember, error) {
+	ctx := context.Background()
+	return c.GetMembersWithContext(ctx)
+}
+
+// GetMembersWithContext lists the organization members, including pending invitations.
+func (c *CCClient) GetMembersWithContext(ctx context.Context) ([]OrganizationMember, error) {
+
+	if c.tracingEnabled {
+		_, span := c.tracer.Start(ctx, "getMembers")
+		defer span.End()
+	}
+
+	data := []OrganizationMember{}
+
+	err := c.apiRequest(ctx, RequestInfo{Operation: "getMembers"}, "GET", "/members", nil, &data)
+
+	return data, err
+}
+
+func (c *CCClient) InviteMember(email string, roles ...MemberRole) (bool, error) {
+	ctx := context.Background()
+	return c.InviteMemberWithContext(ctx, email, roles...)
+}
+
+// InviteMemberWithContext invites a user by email into the organization with the given roles.
+func (c *CCClient) InviteMemberWithContext(ctx context.Context, email string, roles ...MemberRole) (bool, error) {
+
+	if c.tracingEnabled {
+		_, span := c.tracer.Start(ctx, "inviteMember")
+		defer span.End()
+	}
+
+	if err := validateMemberRequest(email, roles); err != nil {
+		return false, err
+	}
+
+	err := c.apiRequest(ctx, RequestInfo{Operation: "inviteMember"}, "POST", "/members/"+url.PathEscape(email), MemberRolesPayload{OrgRoles: roles}, nil)
+
+	return err == nil, err
+}
+
+func (c *CCClient) UpdateMemberRoles(email string, roles ...MemberRole) (bool, error) {
+	ctx := context.Background()
+	return c.UpdateMemberRolesWithContext(ctx, email, roles...)
+}
+
+// UpdateMemberRolesWithContext replaces the roles of an organization member.
+func (c *CCClient) UpdateMemberRolesWithContext(ctx context.Context, email string, roles ...MemberRole) (bool, error) {
+
+	if c.tracingEnabled {
+		_, span := c.tracer.Start(ctx, "updateMemberRoles")
+		defer span.End()
+	}
+
+	if err := validateMemberRequest(email, roles); err != nil {
+		return false, err
+	}
+
+	err := c.apiRequest(ctx, RequestInfo{Operation: "updateMemberRoles"}, "PUT", "/members/"+url.PathEscape(email), MemberRolesPayload{OrgRoles: roles}, nil)
+
+	return err == nil, err
+}
+
+func (c *CCClient) DeleteMember(email string) (bool, error) {
+	ctx := context.Background()
+	return c.DeleteMemberWithContext(ctx, email)
+}
+
+// DeleteMemberWithContext removes a member, or revokes a pending invitation, from the organization.
+func (c *CCClient) DeleteMemberWithContext(ctx context.Context, email string) (bool, error) {
+
+	if c.tracingEnabled {
+		_, span := c.tracer.Start(ctx, "deleteMember")
+		defer span.End()
+	}
+
+	if len(email) == 0 {
+		return false, NewError("Member email should not be empty")
+	}
+
+	err := c.apiRequest(ctx, RequestInfo{Operation: "deleteMember"}, "DELETE", "/members/"+url.PathEscape(email), nil, nil)
+
+	return err == nil, err
+}
+
+func validateMemberRequest(email string, roles []MemberRole) error {
+	if len(email) == 0 {
+		return NewError("Member email should not be empty")
+	}
+
+	if len(roles) == 0 {
+		return NewError("At least one role should be provided")
+	}
+
+	return nil
+}
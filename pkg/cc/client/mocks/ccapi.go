@@ -0,0 +1,1107 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	client "github.com/camunda-community-hub/camunda-cloud-go-client/pkg/cc/client"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// CCAPI is an autogenerated mock type for the CCAPI type
+type CCAPI struct {
+	mock.Mock
+}
+
+// CloneCluster provides a mock function with given fields: ctx, sourceID, newName, opts
+func (_m *CCAPI) CloneCluster(ctx context.Context, sourceID string, newName string, opts client.CloneOptions) (client.CloneReport, error) {
+	ret := _m.Called(ctx, sourceID, newName, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CloneCluster")
+	}
+
+	var r0 client.CloneReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, client.CloneOptions) (client.CloneReport, error)); ok {
+		return rf(ctx, sourceID, newName, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, client.CloneOptions) client.CloneReport); ok {
+		r0 = rf(ctx, sourceID, newName, opts)
+	} else {
+		r0 = ret.Get(0).(client.CloneReport)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, client.CloneOptions) error); ok {
+		r1 = rf(ctx, sourceID, newName, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateClusterCustomConfigWithContext provides a mock function with given fields: ctx, clusterParams
+func (_m *CCAPI) CreateClusterCustomConfigWithContext(ctx context.Context, clusterParams client.ClusterCreationParams) (string, error) {
+	ret := _m.Called(ctx, clusterParams)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateClusterCustomConfigWithContext")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, client.ClusterCreationParams) (string, error)); ok {
+		return rf(ctx, clusterParams)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, client.ClusterCreationParams) string); ok {
+		r0 = rf(ctx, clusterParams)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, client.ClusterCreationParams) error); ok {
+		r1 = rf(ctx, clusterParams)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateClusterDefaultWithContext provides a mock function with given fields: ctx, clusterName
+func (_m *CCAPI) CreateClusterDefaultWithContext(ctx context.Context, clusterName string) (string, error) {
+	ret := _m.Called(ctx, clusterName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateClusterDefaultWithContext")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return rf(ctx, clusterName)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, clusterName)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, clusterName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateClusterFromSpec provides a mock function with given fields: ctx, spec
+func (_m *CCAPI) CreateClusterFromSpec(ctx context.Context, spec client.ClusterSpec) (string, error) {
+	ret := _m.Called(ctx, spec)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateClusterFromSpec")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, client.ClusterSpec) (string, error)); ok {
+		return rf(ctx, spec)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, client.ClusterSpec) string); ok {
+		r0 = rf(ctx, spec)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, client.ClusterSpec) error); ok {
+		r1 = rf(ctx, spec)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateClusterWithParamsAndContext provides a mock function with given fields: ctx, clusterName, clusterPlanName, channelName, generationName, clusterRegion
+func (_m *CCAPI) CreateClusterWithParamsAndContext(ctx context.Context, clusterName string, clusterPlanName string, channelName string, generationName string, clusterRegion string) (string, error) {
+	ret := _m.Called(ctx, clusterName, clusterPlanName, channelName, generationName, clusterRegion)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateClusterWithParamsAndContext")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string, string) (string, error)); ok {
+		return rf(ctx, clusterName, clusterPlanName, channelName, generationName, clusterRegion)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string, string) string); ok {
+		r0 = rf(ctx, clusterName, clusterPlanName, channelName, generationName, clusterRegion)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, string, string) error); ok {
+		r1 = rf(ctx, clusterName, clusterPlanName, channelName, generationName, clusterRegion)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateConnectorSecretWithContext provides a mock function with given fields: ctx, clusterID, name, value
+func (_m *CCAPI) CreateConnectorSecretWithContext(ctx context.Context, clusterID string, name string, value string) (bool, error) {
+	ret := _m.Called(ctx, clusterID, name, value)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateConnectorSecretWithContext")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (bool, error)); ok {
+		return rf(ctx, clusterID, name, value)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) bool); ok {
+		r0 = rf(ctx, clusterID, name, value)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, clusterID, name, value)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateZeebeClientWithContext provides a mock function with given fields: ctx, clusterID, clientName, scopes
+func (_m *CCAPI) CreateZeebeClientWithContext(ctx context.Context, clusterID string, clientName string, scopes ...client.ZeebeClientScope) (client.ZeebeClientCreatedResponse, error) {
+	_va := make([]interface{}, len(scopes))
+	for _i := range scopes {
+		_va[_i] = scopes[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, clusterID, clientName)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateZeebeClientWithContext")
+	}
+
+	var r0 client.ZeebeClientCreatedResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, ...client.ZeebeClientScope) (client.ZeebeClientCreatedResponse, error)); ok {
+		return rf(ctx, clusterID, clientName, scopes...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, ...client.ZeebeClientScope) client.ZeebeClientCreatedResponse); ok {
+		r0 = rf(ctx, clusterID, clientName, scopes...)
+	} else {
+		r0 = ret.Get(0).(client.ZeebeClientCreatedResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, ...client.ZeebeClientScope) error); ok {
+		r1 = rf(ctx, clusterID, clientName, scopes...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteClusterWithContext provides a mock function with given fields: ctx, clusterId
+func (_m *CCAPI) DeleteClusterWithContext(ctx context.Context, clusterId string) (bool, error) {
+	ret := _m.Called(ctx, clusterId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteClusterWithContext")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return rf(ctx, clusterId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, clusterId)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, clusterId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteClustersMatching provides a mock function with given fields: ctx, filter, opts
+func (_m *CCAPI) DeleteClustersMatching(ctx context.Context, filter client.ListOptions, opts client.GCOptions) (client.GCReport, error) {
+	ret := _m.Called(ctx, filter, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteClustersMatching")
+	}
+
+	var r0 client.GCReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, client.ListOptions, client.GCOptions) (client.GCReport, error)); ok {
+		return rf(ctx, filter, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, client.ListOptions, client.GCOptions) client.GCReport); ok {
+		r0 = rf(ctx, filter, opts)
+	} else {
+		r0 = ret.Get(0).(client.GCReport)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, client.ListOptions, client.GCOptions) error); ok {
+		r1 = rf(ctx, filter, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteMemberWithContext provides a mock function with given fields: ctx, email
+func (_m *CCAPI) DeleteMemberWithContext(ctx context.Context, email string) (bool, error) {
+	ret := _m.Called(ctx, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteMemberWithContext")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return rf(ctx, email)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, email)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteZeebeClientWithContext provides a mock function with given fields: ctx, clusterID, clientID
+func (_m *CCAPI) DeleteZeebeClientWithContext(ctx context.Context, clusterID string, clientID string) (bool, error) {
+	ret := _m.Called(ctx, clusterID, clientID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteZeebeClientWithContext")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (bool, error)); ok {
+		return rf(ctx, clusterID, clientID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) bool); ok {
+		r0 = rf(ctx, clusterID, clientID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, clusterID, clientID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EnsureCluster provides a mock function with given fields: ctx, spec
+func (_m *CCAPI) EnsureCluster(ctx context.Context, spec client.ClusterSpec) (client.Cluster, bool, error) {
+	ret := _m.Called(ctx, spec)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnsureCluster")
+	}
+
+	var r0 client.Cluster
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, client.ClusterSpec) (client.Cluster, bool, error)); ok {
+		return rf(ctx, spec)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, client.ClusterSpec) client.Cluster); ok {
+		r0 = rf(ctx, spec)
+	} else {
+		r0 = ret.Get(0).(client.Cluster)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, client.ClusterSpec) bool); ok {
+		r1 = rf(ctx, spec)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, client.ClusterSpec) error); ok {
+		r2 = rf(ctx, spec)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// EnsureZeebeClient provides a mock function with given fields: ctx, clusterID, name, scopes
+func (_m *CCAPI) EnsureZeebeClient(ctx context.Context, clusterID string, name string, scopes ...client.ZeebeClientScope) (client.ZeebeClientCreatedResponse, bool, error) {
+	_va := make([]interface{}, len(scopes))
+	for _i := range scopes {
+		_va[_i] = scopes[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, clusterID, name)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnsureZeebeClient")
+	}
+
+	var r0 client.ZeebeClientCreatedResponse
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, ...client.ZeebeClientScope) (client.ZeebeClientCreatedResponse, bool, error)); ok {
+		return rf(ctx, clusterID, name, scopes...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, ...client.ZeebeClientScope) client.ZeebeClientCreatedResponse); ok {
+		r0 = rf(ctx, clusterID, name, scopes...)
+	} else {
+		r0 = ret.Get(0).(client.ZeebeClientCreatedResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, ...client.ZeebeClientScope) bool); ok {
+		r1 = rf(ctx, clusterID, name, scopes...)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, string, ...client.ZeebeClientScope) error); ok {
+		r2 = rf(ctx, clusterID, name, scopes...)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetClusterByNameWithContext provides a mock function with given fields: ctx, name
+func (_m *CCAPI) GetClusterByNameWithContext(ctx context.Context, name string) (client.Cluster, error) {
+	ret := _m.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetClusterByNameWithContext")
+	}
+
+	var r0 client.Cluster
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (client.Cluster, error)); ok {
+		return rf(ctx, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) client.Cluster); ok {
+		r0 = rf(ctx, name)
+	} else {
+		r0 = ret.Get(0).(client.Cluster)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetClusterDetailsWithContext provides a mock function with given fields: ctx, clusterId
+func (_m *CCAPI) GetClusterDetailsWithContext(ctx context.Context, clusterId string) (client.ClusterStatus, error) {
+	ret := _m.Called(ctx, clusterId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetClusterDetailsWithContext")
+	}
+
+	var r0 client.ClusterStatus
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (client.ClusterStatus, error)); ok {
+		return rf(ctx, clusterId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) client.ClusterStatus); ok {
+		r0 = rf(ctx, clusterId)
+	} else {
+		r0 = ret.Get(0).(client.ClusterStatus)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, clusterId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetClusterParamsWithContext provides a mock function with given fields: ctx
+func (_m *CCAPI) GetClusterParamsWithContext(ctx context.Context) (*client.ClusterParams, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetClusterParamsWithContext")
+	}
+
+	var r0 *client.ClusterParams
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*client.ClusterParams, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *client.ClusterParams); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*client.ClusterParams)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetClusterWithContext provides a mock function with given fields: ctx, clusterID
+func (_m *CCAPI) GetClusterWithContext(ctx context.Context, clusterID string) (client.Cluster, error) {
+	ret := _m.Called(ctx, clusterID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetClusterWithContext")
+	}
+
+	var r0 client.Cluster
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (client.Cluster, error)); ok {
+		return rf(ctx, clusterID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) client.Cluster); ok {
+		r0 = rf(ctx, clusterID)
+	} else {
+		r0 = ret.Get(0).(client.Cluster)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, clusterID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetClustersWithContext provides a mock function with given fields: ctx
+func (_m *CCAPI) GetClustersWithContext(ctx context.Context) ([]client.Cluster, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetClustersWithContext")
+	}
+
+	var r0 []client.Cluster
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]client.Cluster, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []client.Cluster); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]client.Cluster)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetClustersWithStatus provides a mock function with given fields: ctx
+func (_m *CCAPI) GetClustersWithStatus(ctx context.Context) ([]client.Cluster, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetClustersWithStatus")
+	}
+
+	var r0 []client.Cluster
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]client.Cluster, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []client.Cluster); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]client.Cluster)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetConnectorSecretsWithContext provides a mock function with given fields: ctx, clusterID
+func (_m *CCAPI) GetConnectorSecretsWithContext(ctx context.Context, clusterID string) (map[string]string, error) {
+	ret := _m.Called(ctx, clusterID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetConnectorSecretsWithContext")
+	}
+
+	var r0 map[string]string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (map[string]string, error)); ok {
+		return rf(ctx, clusterID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) map[string]string); ok {
+		r0 = rf(ctx, clusterID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, clusterID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMembersWithContext provides a mock function with given fields: ctx
+func (_m *CCAPI) GetMembersWithContext(ctx context.Context) ([]client.OrganizationMember, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMembersWithContext")
+	}
+
+	var r0 []client.OrganizationMember
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]client.OrganizationMember, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []client.OrganizationMember); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]client.OrganizationMember)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetZeebeClientDetailsWithContext provides a mock function with given fields: ctx, clusterID, clientID
+func (_m *CCAPI) GetZeebeClientDetailsWithContext(ctx context.Context, clusterID string, clientID string) (client.ZeebeClientDetailsResponse, error) {
+	ret := _m.Called(ctx, clusterID, clientID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetZeebeClientDetailsWithContext")
+	}
+
+	var r0 client.ZeebeClientDetailsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (client.ZeebeClientDetailsResponse, error)); ok {
+		return rf(ctx, clusterID, clientID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) client.ZeebeClientDetailsResponse); ok {
+		r0 = rf(ctx, clusterID, clientID)
+	} else {
+		r0 = ret.Get(0).(client.ZeebeClientDetailsResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, clusterID, clientID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetZeebeClientsWithContext provides a mock function with given fields: ctx, clusterID
+func (_m *CCAPI) GetZeebeClientsWithContext(ctx context.Context, clusterID string) ([]client.ZeebeClientResponse, error) {
+	ret := _m.Called(ctx, clusterID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetZeebeClientsWithContext")
+	}
+
+	var r0 []client.ZeebeClientResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]client.ZeebeClientResponse, error)); ok {
+		return rf(ctx, clusterID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []client.ZeebeClientResponse); ok {
+		r0 = rf(ctx, clusterID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]client.ZeebeClientResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, clusterID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HasScope provides a mock function with given fields: scope
+func (_m *CCAPI) HasScope(scope client.Scope) bool {
+	ret := _m.Called(scope)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HasScope")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(client.Scope) bool); ok {
+		r0 = rf(scope)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// Inventory provides a mock function with given fields: ctx, filter, opts
+func (_m *CCAPI) Inventory(ctx context.Context, filter client.ListOptions, opts client.InventoryOptions) (client.InventoryReport, error) {
+	ret := _m.Called(ctx, filter, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Inventory")
+	}
+
+	var r0 client.InventoryReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, client.ListOptions, client.InventoryOptions) (client.InventoryReport, error)); ok {
+		return rf(ctx, filter, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, client.ListOptions, client.InventoryOptions) client.InventoryReport); ok {
+		r0 = rf(ctx, filter, opts)
+	} else {
+		r0 = ret.Get(0).(client.InventoryReport)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, client.ListOptions, client.InventoryOptions) error); ok {
+		r1 = rf(ctx, filter, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InviteMemberWithContext provides a mock function with given fields: ctx, email, roles
+func (_m *CCAPI) InviteMemberWithContext(ctx context.Context, email string, roles ...client.MemberRole) (bool, error) {
+	_va := make([]interface{}, len(roles))
+	for _i := range roles {
+		_va[_i] = roles[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, email)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InviteMemberWithContext")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...client.MemberRole) (bool, error)); ok {
+		return rf(ctx, email, roles...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...client.MemberRole) bool); ok {
+		r0 = rf(ctx, email, roles...)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, ...client.MemberRole) error); ok {
+		r1 = rf(ctx, email, roles...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListClusters provides a mock function with given fields: ctx, opts
+func (_m *CCAPI) ListClusters(ctx context.Context, opts client.ListOptions) ([]client.Cluster, error) {
+	ret := _m.Called(ctx, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListClusters")
+	}
+
+	var r0 []client.Cluster
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, client.ListOptions) ([]client.Cluster, error)); ok {
+		return rf(ctx, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, client.ListOptions) []client.Cluster); ok {
+		r0 = rf(ctx, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]client.Cluster)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, client.ListOptions) error); ok {
+		r1 = rf(ctx, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LoginWithContext provides a mock function with given fields: ctx, clientId, clientSecret
+func (_m *CCAPI) LoginWithContext(ctx context.Context, clientId string, clientSecret string) (bool, error) {
+	ret := _m.Called(ctx, clientId, clientSecret)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LoginWithContext")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (bool, error)); ok {
+		return rf(ctx, clientId, clientSecret)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) bool); ok {
+		r0 = rf(ctx, clientId, clientSecret)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, clientId, clientSecret)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PlanRollout provides a mock function with given fields: ctx, filter, target
+func (_m *CCAPI) PlanRollout(ctx context.Context, filter client.ListOptions, target string) (client.RolloutState, error) {
+	ret := _m.Called(ctx, filter, target)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PlanRollout")
+	}
+
+	var r0 client.RolloutState
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, client.ListOptions, string) (client.RolloutState, error)); ok {
+		return rf(ctx, filter, target)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, client.ListOptions, string) client.RolloutState); ok {
+		r0 = rf(ctx, filter, target)
+	} else {
+		r0 = ret.Get(0).(client.RolloutState)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, client.ListOptions, string) error); ok {
+		r1 = rf(ctx, filter, target)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RequireScopes provides a mock function with given fields: scopes
+func (_m *CCAPI) RequireScopes(scopes ...client.Scope) error {
+	_va := make([]interface{}, len(scopes))
+	for _i := range scopes {
+		_va[_i] = scopes[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RequireScopes")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(...client.Scope) error); ok {
+		r0 = rf(scopes...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RolloutGeneration provides a mock function with given fields: ctx, state, opts
+func (_m *CCAPI) RolloutGeneration(ctx context.Context, state *client.RolloutState, opts client.RolloutOptions) error {
+	ret := _m.Called(ctx, state, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RolloutGeneration")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *client.RolloutState, client.RolloutOptions) error); ok {
+		r0 = rf(ctx, state, opts)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetIPAllowlistWithContext provides a mock function with given fields: ctx, clusterID, entries
+func (_m *CCAPI) SetIPAllowlistWithContext(ctx context.Context, clusterID string, entries []client.IPAllowlistEntry) (bool, error) {
+	ret := _m.Called(ctx, clusterID, entries)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetIPAllowlistWithContext")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []client.IPAllowlistEntry) (bool, error)); ok {
+		return rf(ctx, clusterID, entries)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []client.IPAllowlistEntry) bool); ok {
+		r0 = rf(ctx, clusterID, entries)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []client.IPAllowlistEntry) error); ok {
+		r1 = rf(ctx, clusterID, entries)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SnapshotOrganization provides a mock function with given fields: ctx, name
+func (_m *CCAPI) SnapshotOrganization(ctx context.Context, name string) (client.OrganizationSnapshot, error) {
+	ret := _m.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SnapshotOrganization")
+	}
+
+	var r0 client.OrganizationSnapshot
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (client.OrganizationSnapshot, error)); ok {
+		return rf(ctx, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) client.OrganizationSnapshot); ok {
+		r0 = rf(ctx, name)
+	} else {
+		r0 = ret.Get(0).(client.OrganizationSnapshot)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateMemberRolesWithContext provides a mock function with given fields: ctx, email, roles
+func (_m *CCAPI) UpdateMemberRolesWithContext(ctx context.Context, email string, roles ...client.MemberRole) (bool, error) {
+	_va := make([]interface{}, len(roles))
+	for _i := range roles {
+		_va[_i] = roles[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, email)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateMemberRolesWithContext")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...client.MemberRole) (bool, error)); ok {
+		return rf(ctx, email, roles...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...client.MemberRole) bool); ok {
+		r0 = rf(ctx, email, roles...)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, ...client.MemberRole) error); ok {
+		r1 = rf(ctx, email, roles...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateZeebeClientWithContext provides a mock function with given fields: ctx, clusterID, clientID, scopes
+func (_m *CCAPI) UpdateZeebeClientWithContext(ctx context.Context, clusterID string, clientID string, scopes ...client.ZeebeClientScope) (bool, error) {
+	_va := make([]interface{}, len(scopes))
+	for _i := range scopes {
+		_va[_i] = scopes[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, clusterID, clientID)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateZeebeClientWithContext")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, ...client.ZeebeClientScope) (bool, error)); ok {
+		return rf(ctx, clusterID, clientID, scopes...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, ...client.ZeebeClientScope) bool); ok {
+		r0 = rf(ctx, clusterID, clientID, scopes...)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, ...client.ZeebeClientScope) error); ok {
+		r1 = rf(ctx, clusterID, clientID, scopes...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpgradeClusterWithContext provides a mock function with given fields: ctx, clusterID, generationID
+func (_m *CCAPI) UpgradeClusterWithContext(ctx context.Context, clusterID string, generationID string) (bool, error) {
+	ret := _m.Called(ctx, clusterID, generationID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpgradeClusterWithContext")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (bool, error)); ok {
+		return rf(ctx, clusterID, generationID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) bool); ok {
+		r0 = rf(ctx, clusterID, generationID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, clusterID, generationID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WaitForClusterHealthy provides a mock function with given fields: ctx, clusterID, interval
+func (_m *CCAPI) WaitForClusterHealthy(ctx context.Context, clusterID string, interval time.Duration) (client.ClusterStatus, error) {
+	ret := _m.Called(ctx, clusterID, interval)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WaitForClusterHealthy")
+	}
+
+	var r0 client.ClusterStatus
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) (client.ClusterStatus, error)); ok {
+		return rf(ctx, clusterID, interval)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) client.ClusterStatus); ok {
+		r0 = rf(ctx, clusterID, interval)
+	} else {
+		r0 = ret.Get(0).(client.ClusterStatus)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Duration) error); ok {
+		r1 = rf(ctx, clusterID, interval)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewCCAPI creates a new instance of CCAPI. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewCCAPI(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *CCAPI {
+	mock := &CCAPI{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
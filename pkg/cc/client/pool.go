@@ -0,0 +1,36 @@
+package client
+
+import (
+	"context"
+	"sync"
+)
+
+// forEachIndex calls fn for each index from 0 to n-1 with at most concurrency calls at a time,
+// and returns the error of each call. The indexes not started yet when the context is done get
+// its error instead.
+func forEachIndex(ctx context.Context, n int, concurrency int, fn func(i int) error) []error {
+	errs := make([]error, n)
+	pending := make(chan int)
+	var wg sync.WaitGroup
+
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range pending {
+				errs[i] = fn(i)
+			}
+		}()
+	}
+
+	for i := 0; i < n; i++ {
+		select {
+		case pending <- i:
+		case <-ctx.Done():
+			errs[i] = ctx.Err()
+		}
+	}
+	close(pending)
+	wg.Wait()
+	return errs
+}
@@ -0,0 +1,61 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+)
+
+// apiRequest sends an authenticated request to the Camunda Cloud management API, described by info to the interceptors.
+// The payload is sent as JSON when not nil and a successful response is decoded into out when not nil.
+func (c *CCClient) apiRequest(ctx context.Context, info RequestInfo, method string, path string, payload interface{}, out interface{}) error {
+	if err := c.checkScope(info.Operation); err != nil {
+		return err
+	}
+
+	var reqBody io.Reader
+	if payload != nil {
+		jsonStr, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewBuffer(jsonStr)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.apiURL()+path, reqBody)
+	if err != nil {
+		return err
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Authorization", "Bearer "+c.AuthResponsePayload.AccessToken)
+
+	resp, err := c.send(info, req)
+
+	if err != nil {
+		log.Printf("failed to %s, %v", info.Operation, err)
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := c.readBody(resp.Body)
+		return &HTTPError{Operation: info.Operation, StatusCode: resp.StatusCode, Body: string(body)}
+	}
+
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+
+	if err := c.decodeBody(info.Operation, resp.Body, out); err != nil && err != io.EOF {
+		log.Printf("Failed to unmarshal response body ->  %v", err)
+		return err
+	}
+
+	return nil
+}
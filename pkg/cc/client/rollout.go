@@ -0,0 +1,392 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Targets of PlanRollout besides the name or id of a generation.
+const (
+	// RolloutToLatest upgrades each cluster to the newest generation allowed on its channel.
+	RolloutToLatest = "latest"
+	// RolloutToRecommended upgrades each cluster to the default generation of its channel.
+	RolloutToRecommended = "recommended"
+)
+
+const (
+	defaultRolloutWaveSize          = 1
+	defaultRolloutHealthTimeout     = 30 * time.Minute
+	defaultRolloutUpgradeStartGrace = time.Minute
+)
+
+// RolloutStatus is where a cluster is in a rollout.
+type RolloutStatus string
+
+const (
+	RolloutPending   RolloutStatus = "pending"
+	RolloutUpgrading RolloutStatus = "upgrading"
+	RolloutDone      RolloutStatus = "done"
+	RolloutFailed    RolloutStatus = "failed"
+	RolloutSkipped   RolloutStatus = "skipped"
+)
+
+// RolloutCluster is a cluster of a rollout.
+type RolloutCluster struct {
+	ClusterID string `json:"clusterId"`
+	Name      string `json:"name"`
+	// From is the generation of the cluster when the rollout was planned.
+	From string `json:"from"`
+	// To and ToID are the generation the cluster is upgraded to.
+	To   string `json:"to,omitempty"`
+	ToID string `json:"toId,omitempty"`
+	// Wave is the wave the cluster was last upgraded in, starting with 1.
+	Wave   int           `json:"wave,omitempty"`
+	Status RolloutStatus `json:"status"`
+	// Reason tells why a cluster is skipped.
+	Reason string `json:"reason,omitempty"`
+	// Error tells why the upgrade of a cluster failed.
+	Error string `json:"error,omitempty"`
+}
+
+// RolloutState is a rollout planned by PlanRollout and advanced by RolloutGeneration. It is meant to be
+// saved as JSON after every change, so that an interrupted rollout can be resumed.
+type RolloutState struct {
+	// Target is the generation asked for: RolloutToLatest, RolloutToRecommended or a generation name or id.
+	Target   string           `json:"target"`
+	Clusters []RolloutCluster `json:"clusters"`
+}
+
+// Count returns the number of clusters with the status.
+func (s RolloutState) Count(status RolloutStatus) int {
+	count := 0
+	for _, cluster := range s.Clusters {
+		if cluster.Status == status {
+			count++
+		}
+	}
+	return count
+}
+
+// Err returns an error summing the failed upgrades up, nil when none failed.
+func (s RolloutState) Err() error {
+	failures := []string{}
+	for _, cluster := range s.Clusters {
+		if cluster.Status == RolloutFailed {
+			failures = append(failures, cluster.Name+": "+cluster.Error)
+		}
+	}
+	if len(failures) == 0 {
+		return nil
+	}
+	return fmt.Errorf("rollout halted, failed to upgrade %d clusters with %d left: %s",
+		len(failures), s.Count(RolloutPending), strings.Join(failures, "; "))
+}
+
+// RolloutOptions tells RolloutGeneration how fast to go.
+type RolloutOptions struct {
+	// WaveSize is the number of clusters upgraded at the same time, 1 by default.
+	WaveSize int
+	// Pause is the time waited between two waves.
+	Pause time.Duration
+	// HealthTimeout is how long an upgraded cluster may take to be healthy again, 30 minutes by default.
+	HealthTimeout time.Duration
+	// PollInterval is the time between two status requests, DefaultPollInterval by default.
+	PollInterval time.Duration
+	// UpgradeStartGrace is how long a cluster healthy on its target right after the upgrade request
+	// is waited for to report the upgrade, before the upgrade is taken as already done, a minute by default.
+	UpgradeStartGrace time.Duration
+	// Progress is called with the state after every change of a cluster, one call at a time.
+	// An error stops the rollout after the current wave, for example when the state cannot be saved.
+	Progress func(state RolloutState, cluster RolloutCluster) error
+}
+
+// PlanRollout lists the clusters matching the filter and the generation each one would be upgraded to,
+// see RolloutToLatest and RolloutToRecommended. Clusters already on their target or a newer generation,
+// deleting, or on a channel not offering the target are skipped.
+func (c *CCClient) PlanRollout(ctx context.Context, filter ListOptions, target string) (RolloutState, error) {
+
+	if c.tracingEnabled {
+		_, span := c.tracer.Start(ctx, "planRollout")
+		defer span.End()
+	}
+
+	state := RolloutState{Target: target, Clusters: []RolloutCluster{}}
+
+	if len(target) == 0 {
+		return state, NewError("Rollout target should not be empty")
+	}
+
+	params, err := c.GetClusterParamsWithContext(ctx)
+	if err != nil {
+		return state, err
+	}
+	clusters, err := c.ListClusters(ctx, filter)
+	if err != nil {
+		return state, err
+	}
+
+	for _, cluster := range clusters {
+		state.Clusters = append(state.Clusters, planClusterRollout(cluster, *params, target))
+	}
+	return state, nil
+}
+
+func planClusterRollout(cluster Cluster, params ClusterParams, target string) RolloutCluster {
+	item := RolloutCluster{ClusterID: cluster.ID, Name: cluster.Name, From: cluster.Generation.Name, Status: RolloutSkipped}
+
+	channel, found := findChannel(params, cluster.Channel)
+	if !found {
+		item.Reason = "channel " + cluster.Channel.Name + " is not offered anymore"
+		return item
+	}
+	generation, err := rolloutTarget(channel, target)
+	if err != nil {
+		item.Reason = err.Error()
+		return item
+	}
+	item.To, item.ToID = generation.Name, generation.Id
+
+	current, currentKnown := parseGenerationVersion(cluster.Generation.Name)
+	wanted, wantedKnown := parseGenerationVersion(generation.Name)
+	switch {
+	case sameGeneration(cluster.Generation, generation):
+		item.Reason = "already on " + generation.Name
+	case currentKnown && wantedKnown && current.compare(wanted) > 0:
+		item.Reason = "already on a newer generation than " + generation.Name
+	case cluster.Health() == HealthDeleting:
+		item.Reason = "deleting"
+	default:
+		item.Status = RolloutPending
+	}
+	return item
+}
+
+func rolloutTarget(channel Channel, target string) (Generation, error) {
+	switch target {
+	case RolloutToLatest:
+		if generation, found := latestGeneration(channel); found {
+			return generation, nil
+		}
+		return Generation{}, fmt.Errorf("no generation of channel %s has a version", channel.Name)
+	case RolloutToRecommended:
+		return channel.DefaultGeneration, nil
+	}
+	for _, generation := range channel.AllowedGeneration {
+		if paramMatches(generation.Id, generation.Name, target) {
+			return generation, nil
+		}
+	}
+	return Generation{}, fmt.Errorf("generation %s is not allowed on channel %s", target, channel.Name)
+}
+
+func sameGeneration(a Generation, b Generation) bool {
+	return (a.Id != "" && a.Id == b.Id) || (a.Name != "" && a.Name == b.Name)
+}
+
+// RolloutGeneration upgrades the pending clusters of the state in waves of opts.WaveSize, waiting for
+// Zeebe, Operate and Tasklist of each cluster to be healthy on the new generation before the next wave.
+// The rollout halts after a wave in which an upgrade failed. Resuming a state retries the clusters that
+// failed, or were upgrading when the rollout was interrupted. The returned error is about the context or
+// opts.Progress: failed upgrades are in the state, see RolloutState.Err.
+func (c *CCClient) RolloutGeneration(ctx context.Context, state *RolloutState, opts RolloutOptions) error {
+
+	if c.tracingEnabled {
+		_, span := c.tracer.Start(ctx, "rolloutGeneration")
+		defer span.End()
+	}
+
+	if opts.WaveSize <= 0 {
+		opts.WaveSize = defaultRolloutWaveSize
+	}
+	if opts.HealthTimeout <= 0 {
+		opts.HealthTimeout = defaultRolloutHealthTimeout
+	}
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = DefaultPollInterval
+	}
+	if opts.UpgradeStartGrace <= 0 {
+		opts.UpgradeStartGrace = defaultRolloutUpgradeStartGrace
+	}
+
+	r := &rollout{client: c, state: state, opts: opts}
+	for i, cluster := range state.Clusters {
+		if cluster.Status == RolloutFailed || cluster.Status == RolloutUpgrading {
+			state.Clusters[i].Status, state.Clusters[i].Error = RolloutPending, ""
+		}
+		if cluster.Wave > r.wave {
+			r.wave = cluster.Wave
+		}
+	}
+
+	for first := true; ; first = false {
+		wave := []int{}
+		for i, cluster := range state.Clusters {
+			if cluster.Status == RolloutPending && len(wave) < opts.WaveSize {
+				wave = append(wave, i)
+			}
+		}
+		if len(wave) == 0 {
+			return nil
+		}
+
+		if !first && opts.Pause > 0 {
+			timer := time.NewTimer(opts.Pause)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return ctx.Err()
+			case <-timer.C:
+			}
+		}
+
+		r.wave++
+		if err := r.upgradeWave(ctx, wave); err != nil {
+			return err
+		}
+		if state.Count(RolloutFailed) > 0 {
+			return nil
+		}
+	}
+}
+
+// rollout upgrades the clusters of a wave in parallel, updating the state under its lock.
+type rollout struct {
+	client *CCClient
+	state  *RolloutState
+	opts   RolloutOptions
+	wave   int
+
+	mu  sync.Mutex
+	err error
+}
+
+func (r *rollout) upgradeWave(ctx context.Context, wave []int) error {
+	var wg sync.WaitGroup
+	for _, i := range wave {
+		r.update(i, RolloutUpgrading, nil)
+		wg.Add(1)
+		go func(i int, item RolloutCluster) {
+			defer wg.Done()
+			err := r.upgrade(ctx, item)
+			if ctx.Err() != nil {
+				// Interrupted, the cluster stays upgrading until the rollout is resumed.
+				return
+			}
+			if err != nil {
+				r.update(i, RolloutFailed, err)
+			} else {
+				r.update(i, RolloutDone, nil)
+			}
+		}(i, r.state.Clusters[i])
+	}
+	wg.Wait()
+
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return r.err
+}
+
+func (r *rollout) update(i int, status RolloutStatus, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cluster := &r.state.Clusters[i]
+	cluster.Status, cluster.Error = status, ""
+	if status == RolloutUpgrading {
+		cluster.Wave = r.wave
+	}
+	if err != nil {
+		cluster.Error = err.Error()
+	}
+	if r.opts.Progress != nil {
+		if err := r.opts.Progress(*r.state, *cluster); err != nil && r.err == nil {
+			r.err = err
+		}
+	}
+}
+
+// upgrade starts the upgrade of the cluster unless it is already on its target, as when resuming,
+// and waits for it to be healthy on the target. After starting an upgrade, the cluster has to be
+// seen leaving Healthy first: until the upgrade starts, the API may report the new generation with
+// the status of the old one. A cluster still healthy on the target after UpgradeStartGrace is taken
+// as upgraded between two polls. Transport errors, 429 and 5xx answers are polled through.
+func (r *rollout) upgrade(ctx context.Context, item RolloutCluster) error {
+	target := Generation{Id: item.ToID, Name: item.To}
+
+	cluster, err := r.client.GetClusterWithContext(ctx, item.ClusterID)
+	if err != nil {
+		return err
+	}
+	started := true
+	requested := time.Now()
+	if !sameGeneration(cluster.Generation, target) {
+		if _, err := r.client.UpgradeClusterWithContext(ctx, item.ClusterID, target.Id); err != nil {
+			return err
+		}
+		started = false
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, r.opts.HealthTimeout)
+	defer cancel()
+	for {
+		timer := time.NewTimer(r.opts.PollInterval)
+		select {
+		case <-waitCtx.Done():
+			timer.Stop()
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if !started {
+				return fmt.Errorf("not healthy on %s after %s, the upgrade did not start: %s", target.Name, r.opts.HealthTimeout, describeStatus(cluster))
+			}
+			return fmt.Errorf("not healthy on %s after %s: %s", target.Name, r.opts.HealthTimeout, describeStatus(cluster))
+		case <-timer.C:
+		}
+
+		current, err := r.client.GetClusterWithContext(waitCtx, item.ClusterID)
+		if err != nil {
+			if waitCtx.Err() == nil && !isTransient(err) {
+				return err
+			}
+			continue
+		}
+		cluster = current
+		if !started {
+			started = !upgradedTo(current, target)
+			if !started && time.Since(requested) >= r.opts.UpgradeStartGrace {
+				return nil
+			}
+			continue
+		}
+		if upgradedTo(current, target) {
+			return nil
+		}
+	}
+}
+
+// upgradedTo tells whether the cluster is on the generation with Zeebe, Operate and Tasklist healthy.
+// Components the status leaves out are not waited for.
+func upgradedTo(cluster Cluster, generation Generation) bool {
+	if !sameGeneration(cluster.Generation, generation) {
+		return false
+	}
+	status := cluster.Status
+	for _, health := range []Health{status.ZeebeStatus, status.OperateStatus, status.TaskListStatus} {
+		if health != "" && health != HealthHealthy {
+			return false
+		}
+	}
+	return status.ZeebeStatus == HealthHealthy || (status.ZeebeStatus == "" && status.IsReady())
+}
+
+func describeStatus(cluster Cluster) string {
+	parts := []string{"generation " + cluster.Generation.Name}
+	for _, component := range cluster.Status.Components() {
+		parts = append(parts, component.Name+" "+string(component.Health))
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,229 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/camunda-community-hub/camunda-cloud-go-client/pkg/cc/cctest"
+	cc "github.com/camunda-community-hub/camunda-cloud-go-client/pkg/cc/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func addClusterOn(srv *cctest.Server, name string, channel int, generation cc.Generation) cc.Cluster {
+	return srv.AddCluster(cc.Cluster{Name: name, Channel: srv.Params().Channels[channel], Generation: generation})
+}
+
+var (
+	zeebe0261  = cc.Generation{Id: "generation-stable-1", Name: "Zeebe 0.26.1"}
+	zeebe100   = cc.Generation{Id: "generation-stable-2", Name: "Zeebe 1.0.0"}
+	zeebeAlpha = cc.Generation{Id: "generation-alpha-1", Name: "Zeebe 1.1.0-alpha1"}
+)
+
+func Test_PlanRollout(t *testing.T) {
+	srv := cctest.NewServer()
+	defer srv.Close()
+	ccClient := srv.Client()
+	old := addClusterOn(srv, "old", 0, zeebe0261)
+	current := addClusterOn(srv, "current", 0, zeebe100)
+	alpha := addClusterOn(srv, "alpha", 1, zeebeAlpha)
+
+	state, err := ccClient.PlanRollout(context.Background(), cc.ListOptions{SortBy: cc.SortByName}, cc.RolloutToLatest)
+
+	assert.NoError(t, err)
+	assert.Equal(t, cc.RolloutState{Target: "latest", Clusters: []cc.RolloutCluster{
+		{ClusterID: alpha.ID, Name: "alpha", From: "Zeebe 1.1.0-alpha1", To: "Zeebe 1.1.0-alpha1", ToID: "generation-alpha-1",
+			Status: cc.RolloutSkipped, Reason: "already on Zeebe 1.1.0-alpha1"},
+		{ClusterID: current.ID, Name: "current", From: "Zeebe 1.0.0", To: "Zeebe 1.0.0", ToID: "generation-stable-2",
+			Status: cc.RolloutSkipped, Reason: "already on Zeebe 1.0.0"},
+		{ClusterID: old.ID, Name: "old", From: "Zeebe 0.26.1", To: "Zeebe 1.0.0", ToID: "generation-stable-2", Status: cc.RolloutPending},
+	}}, state)
+
+	state, err = ccClient.PlanRollout(context.Background(), cc.ListOptions{SortBy: cc.SortByName}, "Zeebe 0.26.1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "generation Zeebe 0.26.1 is not allowed on channel Alpha", state.Clusters[0].Reason)
+	assert.Equal(t, "already on a newer generation than Zeebe 0.26.1", state.Clusters[1].Reason)
+	assert.Equal(t, "already on Zeebe 0.26.1", state.Clusters[2].Reason)
+	assert.Equal(t, 0, state.Count(cc.RolloutPending))
+}
+
+func Test_RolloutGeneration(t *testing.T) {
+	srv := cctest.NewServer()
+	defer srv.Close()
+	srv.UpdatingPolls = 2
+	ccClient := srv.Client()
+	for _, name := range []string{"a", "b", "c"} {
+		addClusterOn(srv, name, 0, zeebe0261)
+	}
+
+	state, err := ccClient.PlanRollout(context.Background(), cc.ListOptions{SortBy: cc.SortByName}, cc.RolloutToLatest)
+	assert.NoError(t, err)
+
+	changes := []string{}
+	err = ccClient.RolloutGeneration(context.Background(), &state, cc.RolloutOptions{
+		WaveSize:     2,
+		PollInterval: time.Millisecond,
+		Progress: func(_ cc.RolloutState, cluster cc.RolloutCluster) error {
+			changes = append(changes, cluster.Name+" "+string(cluster.Status))
+			return nil
+		},
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, state.Err())
+	assert.Equal(t, 3, state.Count(cc.RolloutDone))
+	assert.Equal(t, []int{1, 1, 2}, []int{state.Clusters[0].Wave, state.Clusters[1].Wave, state.Clusters[2].Wave})
+	assert.ElementsMatch(t, []string{"a upgrading", "b upgrading", "a done", "b done"}, changes[:4])
+	assert.Equal(t, []string{"c upgrading", "c done"}, changes[4:])
+	for _, cluster := range srv.Clusters() {
+		assert.Equal(t, zeebe100, cluster.Generation)
+		assert.Equal(t, cc.HealthHealthy, cluster.Status.Ready)
+	}
+	assert.Len(t, srv.RequestsTo("PUT /clusters/{clusterId}/generation"), 3)
+}
+
+func Test_RolloutGeneration_haltsAndResumes(t *testing.T) {
+	srv := cctest.NewServer()
+	defer srv.Close()
+	ccClient := srv.Client()
+	for _, name := range []string{"a", "b", "c"} {
+		addClusterOn(srv, name, 0, zeebe0261)
+	}
+	srv.InjectFault("PUT /clusters/{clusterId}/generation", cctest.Fail(500, 1))
+	opts := cc.RolloutOptions{PollInterval: time.Millisecond}
+
+	state, err := ccClient.PlanRollout(context.Background(), cc.ListOptions{SortBy: cc.SortByName}, cc.RolloutToRecommended)
+	assert.NoError(t, err)
+	err = ccClient.RolloutGeneration(context.Background(), &state, opts)
+
+	assert.NoError(t, err)
+	assert.EqualError(t, state.Err(), "rollout halted, failed to upgrade 1 clusters with 2 left: a: HTTP Error trying to upgradeCluster: 500")
+	assert.Equal(t, cc.RolloutFailed, state.Clusters[0].Status)
+	assert.Equal(t, 2, state.Count(cc.RolloutPending))
+
+	err = ccClient.RolloutGeneration(context.Background(), &state, opts)
+
+	assert.NoError(t, err)
+	assert.NoError(t, state.Err())
+	assert.Equal(t, 3, state.Count(cc.RolloutDone))
+	assert.Equal(t, 2, state.Clusters[0].Wave, "the failed cluster is retried in a new wave")
+	assert.Equal(t, 4, state.Clusters[2].Wave)
+}
+
+func Test_RolloutGeneration_resumesInterruptedUpgrade(t *testing.T) {
+	srv := cctest.NewServer()
+	defer srv.Close()
+	ccClient := srv.Client()
+	cluster := srv.AddCluster(cc.Cluster{Name: "a", Channel: srv.Params().Channels[0], Generation: zeebe100,
+		Status: cc.ClusterStatus{Ready: cc.HealthUpdating, ZeebeStatus: cc.HealthUpdating}})
+	state := cc.RolloutState{Target: "latest", Clusters: []cc.RolloutCluster{{ClusterID: cluster.ID, Name: "a",
+		From: "Zeebe 0.26.1", To: "Zeebe 1.0.0", ToID: "generation-stable-2", Wave: 1, Status: cc.RolloutUpgrading}}}
+
+	err := ccClient.RolloutGeneration(context.Background(), &state, cc.RolloutOptions{PollInterval: time.Millisecond})
+
+	assert.NoError(t, err)
+	assert.Equal(t, cc.RolloutDone, state.Clusters[0].Status)
+	assert.Empty(t, srv.RequestsTo("PUT /clusters/{clusterId}/generation"), "the cluster is already on its target")
+}
+
+func Test_RolloutGeneration_waitsForUpgradeToStart(t *testing.T) {
+	srv := cctest.NewServer()
+	defer srv.Close()
+	srv.UpgradeStartPolls = 3
+	ccClient := srv.Client()
+	addClusterOn(srv, "a", 0, zeebe0261)
+
+	state, err := ccClient.PlanRollout(context.Background(), cc.ListOptions{}, cc.RolloutToLatest)
+	assert.NoError(t, err)
+	srv.ResetRequests()
+	err = ccClient.RolloutGeneration(context.Background(), &state, cc.RolloutOptions{PollInterval: time.Millisecond})
+
+	assert.NoError(t, err)
+	assert.Equal(t, cc.RolloutDone, state.Clusters[0].Status)
+	// Before the upgrade, 3 polls still Healthy on the new generation, then Updating and Healthy again.
+	assert.Len(t, srv.RequestsTo("GET /clusters/{clusterId}"), 6)
+	assert.Equal(t, cc.HealthHealthy, srv.Clusters()[0].Status.Ready)
+}
+
+func Test_RolloutGeneration_upgradeDoneBetweenPolls(t *testing.T) {
+	srv := cctest.NewServer()
+	defer srv.Close()
+	srv.InstantUpgrades = true
+	ccClient := srv.Client()
+	addClusterOn(srv, "a", 0, zeebe0261)
+	addClusterOn(srv, "b", 0, zeebe0261)
+
+	state, err := ccClient.PlanRollout(context.Background(), cc.ListOptions{}, cc.RolloutToLatest)
+	assert.NoError(t, err)
+	err = ccClient.RolloutGeneration(context.Background(), &state, cc.RolloutOptions{
+		PollInterval: time.Millisecond, UpgradeStartGrace: 20 * time.Millisecond, HealthTimeout: time.Second})
+
+	assert.NoError(t, err)
+	assert.NoError(t, state.Err())
+	for _, cluster := range state.Clusters {
+		assert.Equal(t, cc.RolloutDone, cluster.Status, cluster.Name)
+	}
+	assert.Len(t, srv.RequestsTo("PUT /clusters/{clusterId}/generation"), 2)
+}
+
+func Test_RolloutGeneration_transientErrors(t *testing.T) {
+	srv := cctest.NewServer()
+	defer srv.Close()
+	ccClient := srv.Client()
+	addClusterOn(srv, "a", 0, zeebe0261)
+	ccClient.Use(func(info cc.RequestInfo, req *http.Request, next cc.Next) (*http.Response, error) {
+		if info.Operation == "upgradeCluster" {
+			srv.InjectFault("GET /clusters/{clusterId}", cctest.Fail(http.StatusServiceUnavailable, 1))
+			srv.InjectFault("GET /clusters/{clusterId}", cctest.RateLimit(time.Second, 1))
+		}
+		return next(req)
+	})
+
+	state, err := ccClient.PlanRollout(context.Background(), cc.ListOptions{}, cc.RolloutToLatest)
+	assert.NoError(t, err)
+	err = ccClient.RolloutGeneration(context.Background(), &state, cc.RolloutOptions{PollInterval: time.Millisecond})
+
+	assert.NoError(t, err)
+	assert.NoError(t, state.Err())
+	assert.Equal(t, cc.RolloutDone, state.Clusters[0].Status)
+}
+
+func Test_RolloutGeneration_healthTimeout(t *testing.T) {
+	srv := cctest.NewServer()
+	defer srv.Close()
+	srv.UpdatingPolls = 1000000
+	ccClient := srv.Client()
+	addClusterOn(srv, "a", 0, zeebe0261)
+	addClusterOn(srv, "b", 0, zeebe0261)
+
+	state, err := ccClient.PlanRollout(context.Background(), cc.ListOptions{SortBy: cc.SortByName}, cc.RolloutToLatest)
+	assert.NoError(t, err)
+	err = ccClient.RolloutGeneration(context.Background(), &state, cc.RolloutOptions{
+		HealthTimeout: 20 * time.Millisecond,
+		PollInterval:  time.Millisecond,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []cc.RolloutStatus{cc.RolloutFailed, cc.RolloutPending}, []cc.RolloutStatus{state.Clusters[0].Status, state.Clusters[1].Status})
+	assert.Equal(t, "not healthy on Zeebe 1.0.0 after 20ms: generation Zeebe 1.0.0, zeebe Updating, operate Updating, tasklist Updating",
+		state.Clusters[0].Error)
+}
+
+func Test_RolloutGeneration_canceled(t *testing.T) {
+	srv := cctest.NewServer()
+	defer srv.Close()
+	srv.UpdatingPolls = 1000000
+	ccClient := srv.Client()
+	addClusterOn(srv, "a", 0, zeebe0261)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	state, err := ccClient.PlanRollout(ctx, cc.ListOptions{}, cc.RolloutToLatest)
+	assert.NoError(t, err)
+	err = ccClient.RolloutGeneration(ctx, &state, cc.RolloutOptions{PollInterval: time.Millisecond})
+
+	assert.Equal(t, context.DeadlineExceeded, err)
+	assert.Equal(t, cc.RolloutUpgrading, state.Clusters[0].Status, "the cluster is resumed from upgrading")
+}
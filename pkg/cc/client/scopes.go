@@ -0,0 +1,116 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Scope is a permission of a Cloud Management API client, granted in the Console
+// and listed in the scope of its access tokens.
+type Scope string
+
+const (
+	ScopeCluster     Scope = "Cluster"
+	ScopeZeebeClient Scope = "ZeebeClient"
+	ScopeMembers     Scope = "Members"
+	ScopeSecrets     Scope = "Secrets"
+)
+
+// Scopes lists every scope known to this client.
+var Scopes = []Scope{
+	ScopeCluster,
+	ScopeZeebeClient,
+	ScopeMembers,
+	ScopeSecrets,
+}
+
+// ErrMissingScope is matched, with errors.Is, by the errors of operations the token is not allowed to call.
+var ErrMissingScope = errors.New("missing scope")
+
+// MissingScopeError is returned, before any request is sent, by an operation whose scope the token lacks.
+type MissingScopeError struct {
+	Operation string
+	Scope     Scope
+}
+
+func (e *MissingScopeError) Error() string {
+	return fmt.Sprintf("%s: %s needs the %s scope, grant it to the API client in the Console", ErrMissingScope, e.Operation, e.Scope)
+}
+
+// Is makes errors.Is(err, ErrMissingScope) true.
+func (e *MissingScopeError) Is(target error) bool {
+	return target == ErrMissingScope
+}
+
+// operationScopes maps the operations of the client to the scope they need.
+var operationScopes = map[string]Scope{
+	"getClusterParams":      ScopeCluster,
+	"getClusters":           ScopeCluster,
+	"getClusterDetails":     ScopeCluster,
+	"getCluster":            ScopeCluster,
+	"setIPAllowlist":        ScopeCluster,
+	"upgradeCluster":        ScopeCluster,
+	"createCluster":         ScopeCluster,
+	"deleteCluster":         ScopeCluster,
+	"getZeebeClients":       ScopeZeebeClient,
+	"getZeebeClientDetails": ScopeZeebeClient,
+	"createZeebeClient":     ScopeZeebeClient,
+	"updateZeebeClient":     ScopeZeebeClient,
+	"deleteZeebeClient":     ScopeZeebeClient,
+	"getMembers":            ScopeMembers,
+	"inviteMember":          ScopeMembers,
+	"updateMemberRoles":     ScopeMembers,
+	"deleteMember":          ScopeMembers,
+	"getConnectorSecrets":   ScopeSecrets,
+	"createConnectorSecret": ScopeSecrets,
+}
+
+// GrantedScopes returns the scopes of the current token, empty before login
+// or when the login server does not list them.
+func (c *CCClient) GrantedScopes() []Scope {
+	scopes := []Scope{}
+	for _, name := range strings.Fields(strings.ReplaceAll(c.AuthResponsePayload.Scope, ",", " ")) {
+		scopes = append(scopes, Scope(name))
+	}
+	return scopes
+}
+
+// HasScope tells whether the current token was granted the scope, ignoring case.
+func (c *CCClient) HasScope(scope Scope) bool {
+	for _, granted := range c.GrantedScopes() {
+		if strings.EqualFold(string(granted), string(scope)) {
+			return true
+		}
+	}
+	return false
+}
+
+// RequireScopes returns a MissingScopeError for the first scope the token lacks, so that
+// workflows of several calls can fail before the first one instead of half way through.
+// Like the operations, it passes when the token does not list its scopes.
+func (c *CCClient) RequireScopes(scopes ...Scope) error {
+	if !c.scopesKnown() {
+		return nil
+	}
+	for _, scope := range scopes {
+		if !c.HasScope(scope) {
+			return &MissingScopeError{Operation: "requireScopes", Scope: scope}
+		}
+	}
+	return nil
+}
+
+// checkScope fails fast when the operation needs a scope the token lacks. Tokens without
+// a scope are not checked: the API decides, as it did before scopes were known here.
+func (c *CCClient) checkScope(operation string) error {
+	scope, found := operationScopes[operation]
+	if !found || !c.scopesKnown() || c.HasScope(scope) {
+		return nil
+	}
+	return &MissingScopeError{Operation: operation, Scope: scope}
+}
+
+func (c *CCClient) scopesKnown() bool {
+	return strings.TrimSpace(c.AuthResponsePayload.Scope) != ""
+}
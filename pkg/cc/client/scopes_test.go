@@ -0,0 +1,49 @@
+package client_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/camunda-community-hub/camunda-cloud-go-client/pkg/cc/cctest"
+	cc "github.com/camunda-community-hub/camunda-cloud-go-client/pkg/cc/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_MissingScope(t *testing.T) {
+	srv := cctest.NewServer()
+	defer srv.Close()
+	srv.Scope = "cluster"
+	ccClient := srv.Client()
+
+	assert.True(t, ccClient.HasScope(cc.ScopeCluster))
+	assert.False(t, ccClient.HasScope(cc.ScopeZeebeClient))
+	assert.Equal(t, []cc.Scope{"cluster"}, ccClient.GrantedScopes())
+
+	clusterID, err := ccClient.CreateClusterDefault("my-cluster")
+	assert.NoError(t, err)
+	sent := len(srv.Requests())
+
+	_, err = ccClient.CreateZeebeClient(clusterID, "worker")
+	assert.True(t, errors.Is(err, cc.ErrMissingScope))
+	assert.EqualError(t, err, "missing scope: createZeebeClient needs the ZeebeClient scope, grant it to the API client in the Console")
+	_, err = ccClient.GetMembers()
+	missing := &cc.MissingScopeError{}
+	assert.True(t, errors.As(err, &missing))
+	assert.Equal(t, cc.ScopeMembers, missing.Scope)
+	assert.Len(t, srv.Requests(), sent, "no request is sent without the scope")
+
+	assert.NoError(t, ccClient.RequireScopes(cc.ScopeCluster))
+	assert.True(t, errors.Is(ccClient.RequireScopes(cc.ScopeCluster, cc.ScopeZeebeClient), cc.ErrMissingScope))
+}
+
+func Test_MissingScope_unlistedScopes(t *testing.T) {
+	srv := cctest.NewServer()
+	defer srv.Close()
+	srv.Scope = ""
+	ccClient := srv.Client()
+
+	assert.False(t, ccClient.HasScope(cc.ScopeMembers))
+	assert.NoError(t, ccClient.RequireScopes(cc.ScopeMembers))
+	_, err := ccClient.GetMembers()
+	assert.NoError(t, err, "the API decides when the token does not list its scopes")
+}
@@ -0,0 +1,55 @@
+package client
+
+import (
+	"context"
+)
+
+func (c *CCClient) GetConnectorSecrets(clusterID string) (map[string]string, error) {
+	ctx := context.Background()
+	return c.GetConnectorSecretsWithContext(ctx, clusterID)
+}
+
+// GetConnectorSecretsWithContext returns the connector secrets of a cluster, by name.
+func (c *CCClient) GetConnectorSecretsWithContext(ctx context.Context, clusterID string) (map[string]string, error) {
+
+	if c.tracingEnabled {
+		_, span := c.tracer.Start(ctx, "getConnectorSecrets")
+		defer span.End()
+	}
+
+	data := map[string]string{}
+
+	if len(clusterID) == 0 {
+		return data, NewError("Cluster id should not be empty")
+	}
+
+	err := c.apiRequest(ctx, RequestInfo{Operation: "getConnectorSecrets", ClusterID: clusterID}, "GET", "/clusters/"+clusterID+"/secrets", nil, &data)
+
+	return data, err
+}
+
+func (c *CCClient) CreateConnectorSecret(clusterID string, name string, value string) (bool, error) {
+	ctx := context.Background()
+	return c.CreateConnectorSecretWithContext(ctx, clusterID, name, value)
+}
+
+// CreateConnectorSecretWithContext adds a connector secret to a cluster.
+func (c *CCClient) CreateConnectorSecretWithContext(ctx context.Context, clusterID string, name string, value string) (bool, error) {
+
+	if c.tracingEnabled {
+		_, span := c.tracer.Start(ctx, "createConnectorSecret")
+		defer span.End()
+	}
+
+	if len(clusterID) == 0 {
+		return false, NewError("Cluster id should not be empty")
+	}
+
+	if len(name) == 0 {
+		return false, NewError("Secret name should not be empty")
+	}
+
+	err := c.apiRequest(ctx, RequestInfo{Operation: "createConnectorSecret", ClusterID: clusterID}, "POST", "/clusters/"+clusterID+"/secrets", ConnectorSecretPayload{SecretName: name, SecretValue: value}, nil)
+
+	return err == nil, err
+}
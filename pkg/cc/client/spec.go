@@ -0,0 +1,121 @@
+package client
+
+import (
+	"context"
+	"strings"
+)
+
+// ClusterSpec describes the cluster EnsureCluster makes sure exists. Plan, channel, generation
+// and region are names or ids of the cluster params. When empty, the defaults of
+// CreateClusterDefaultWithContext are used: Development, Stable, its default generation, the first region.
+type ClusterSpec struct {
+	Name       string `json:"name"`
+	Plan       string `json:"plan,omitempty"`
+	Channel    string `json:"channel,omitempty"`
+	Generation string `json:"generation,omitempty"`
+	Region     string `json:"region,omitempty"`
+}
+
+// CreateClusterFromSpec creates the cluster described by the spec. Unlike CreateClusterWithParamsAndContext,
+// names or ids that are not in the cluster params are an error instead of being sent empty.
+func (c *CCClient) CreateClusterFromSpec(ctx context.Context, spec ClusterSpec) (string, error) {
+
+	if c.tracingEnabled {
+		_, span := c.tracer.Start(ctx, "createClusterFromSpec")
+		defer span.End()
+	}
+
+	if spec.Name == "" {
+		return "", NewError("Cluster name should not be empty")
+	}
+
+	resolved, err := c.resolveClusterSpec(spec)
+	if err != nil {
+		return "", err
+	}
+	return c.createResolvedCluster(ctx, spec.Name, resolved)
+}
+
+func (c *CCClient) createResolvedCluster(ctx context.Context, name string, resolved resolvedClusterSpec) (string, error) {
+	return c.CreateClusterCustomConfigWithContext(ctx, NewClusterCreationParams(name,
+		resolved.channel.Id, resolved.generation.Id, resolved.region.Id, resolved.plan.Id))
+}
+
+// resolvedClusterSpec are the cluster params a spec selects.
+type resolvedClusterSpec struct {
+	plan       ClusterPlantType
+	channel    Channel
+	generation Generation
+	region     Region
+}
+
+// resolveClusterSpec finds the cluster params of the spec, by name or id.
+func (c *CCClient) resolveClusterSpec(spec ClusterSpec) (resolvedClusterSpec, error) {
+	resolved := resolvedClusterSpec{}
+	params := c.ClusterParams
+
+	planName := spec.Plan
+	if planName == "" {
+		planName = "Development"
+	}
+	found := false
+	for _, plan := range params.ClusterPlanTypes {
+		if paramMatches(plan.Id, plan.Name, planName) {
+			resolved.plan, found = plan, true
+		}
+	}
+	if !found {
+		return resolved, NewError("No plan found with name or id: " + planName)
+	}
+
+	channelName := spec.Channel
+	if channelName == "" {
+		channelName = "Stable"
+	}
+	found = false
+	for _, channel := range params.Channels {
+		if paramMatches(channel.Id, channel.Name, channelName) {
+			resolved.channel, found = channel, true
+		}
+	}
+	if !found {
+		return resolved, NewError("No channel found with name or id: " + channelName)
+	}
+
+	if spec.Generation == "" {
+		resolved.generation = resolved.channel.DefaultGeneration
+	} else {
+		found = false
+		for _, generation := range resolved.channel.AllowedGeneration {
+			if paramMatches(generation.Id, generation.Name, spec.Generation) {
+				resolved.generation, found = generation, true
+			}
+		}
+		if !found {
+			return resolved, NewError("No generation found with name or id " + spec.Generation + " in channel " + resolved.channel.Name)
+		}
+	}
+
+	if spec.Region == "" {
+		if len(params.Regions) == 0 {
+			return resolved, NewError("No region available")
+		}
+		resolved.region = params.Regions[0]
+	} else {
+		found = false
+		for _, region := range params.Regions {
+			if paramMatches(region.Id, region.Name, spec.Region) {
+				resolved.region, found = region, true
+			}
+		}
+		if !found {
+			return resolved, NewError("No region found with name or id: " + spec.Region)
+		}
+	}
+
+	return resolved, nil
+}
+
+func paramMatches(id string, name string, value string) bool {
+	return id == value || strings.EqualFold(name, value)
+}
@@ -0,0 +1,30 @@
+package client_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/camunda-community-hub/camunda-cloud-go-client/pkg/cc/cctest"
+	cc "github.com/camunda-community-hub/camunda-cloud-go-client/pkg/cc/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CreateClusterFromSpec(t *testing.T) {
+	srv := cctest.NewServer()
+	defer srv.Close()
+	ccClient := srv.Client()
+
+	clusterID, err := ccClient.CreateClusterFromSpec(context.Background(), cc.ClusterSpec{
+		Name: "orders", Plan: "production - s", Channel: "Stable", Generation: "Zeebe 0.26.1", Region: "region-us-east1",
+	})
+
+	assert.NoError(t, err)
+	cluster := srv.Clusters()[0]
+	assert.Equal(t, clusterID, cluster.ID)
+	assert.Equal(t, "plan-production-s", cluster.ClusterPlantType.Id)
+	assert.Equal(t, "generation-stable-1", cluster.Generation.Id)
+	assert.Equal(t, "region-us-east1", cluster.K8sContext.UUID)
+
+	_, err = ccClient.CreateClusterFromSpec(context.Background(), cc.ClusterSpec{Name: "payments", Channel: "Alpha", Generation: "Zeebe 0.26.1"})
+	assert.EqualError(t, err, "No generation found with name or id Zeebe 0.26.1 in channel Alpha")
+}
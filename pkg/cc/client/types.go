@@ -1,18 +1,116 @@
 package client
 
+import (
+	"encoding/json"
+	"strings"
+	"time"
+)
+
 type ClusterStatusResponse struct {
 	ClusterId     string        `json:"uuid"`
 	ClusterStatus ClusterStatus `json:"status"`
 }
 
 type ClusterStatus struct {
-	OperateStatus  string `json:"operateStatus"`
+	OperateStatus  Health `json:"operateStatus"`
 	OperateURL     string `json:"operateUrl"`
-	Ready          string `json:"ready"`
-	ZeebeStatus    string `json:"zeebeStatus"`
+	Ready          Health `json:"ready"`
+	ZeebeStatus    Health `json:"zeebeStatus"`
 	ZeebeURL       string `json:"zeebeUrl"`
-	TaskListStatus string `json:"tasklistStatus"`
+	TaskListStatus Health `json:"tasklistStatus"`
 	TaskListURL    string `json:"tasklistUrl"`
+	OptimizeStatus Health `json:"optimizeStatus,omitempty"`
+	OptimizeURL    string `json:"optimizeUrl,omitempty"`
+}
+
+// IsReady reports whether Camunda Cloud considers the cluster ready.
+func (s ClusterStatus) IsReady() bool {
+	return s.Ready == HealthHealthy
+}
+
+// IsDegraded reports whether the cluster, or one of its components, is unhealthy
+// or reports a health this client does not know. An empty status, as in listings
+// without status, is not degraded.
+func (s ClusterStatus) IsDegraded() bool {
+	health := s.Health()
+	if health == "" {
+		return false
+	}
+	return health == HealthUnhealthy || !health.IsKnown()
+}
+
+// Components returns the health of every component reported in the status.
+func (s ClusterStatus) Components() []ComponentHealth {
+	components := []ComponentHealth{}
+	for _, component := range []ComponentHealth{
+		{Name: "zeebe", Health: s.ZeebeStatus},
+		{Name: "operate", Health: s.OperateStatus},
+		{Name: "tasklist", Health: s.TaskListStatus},
+		{Name: "optimize", Health: s.OptimizeStatus},
+	} {
+		if component.Health != "" {
+			components = append(components, component)
+		}
+	}
+	return components
+}
+
+// Health aggregates the readiness and the component health into the worst health reported.
+func (s ClusterStatus) Health() Health {
+	health := s.Ready
+	for _, component := range s.Components() {
+		if component.Health.severity() > health.severity() {
+			health = component.Health
+		}
+	}
+	return health
+}
+
+// ComponentHealth is the health of a single component of a cluster.
+type ComponentHealth struct {
+	Name   string `json:"name"`
+	Health Health `json:"health"`
+}
+
+// Health is the health reported by Camunda Cloud for a cluster or one of its components.
+// Values unknown to this client are kept as they were received.
+type Health string
+
+const (
+	HealthHealthy   Health = "Healthy"
+	HealthCreating  Health = "Creating"
+	HealthUpdating  Health = "Updating"
+	HealthSuspended Health = "Suspended"
+	HealthDeleting  Health = "Deleting"
+	HealthUnhealthy Health = "Unhealthy"
+	HealthNotFound  Health = "Not Found"
+)
+
+// IsKnown reports whether the health is one of the values defined by this client.
+func (h Health) IsKnown() bool {
+	switch h {
+	case HealthHealthy, HealthCreating, HealthUpdating, HealthSuspended, HealthDeleting, HealthUnhealthy, HealthNotFound:
+		return true
+	}
+	return false
+}
+
+func (h Health) severity() int {
+	switch h {
+	case "":
+		return -1
+	case HealthHealthy:
+		return 0
+	case HealthCreating, HealthUpdating, HealthDeleting:
+		return 1
+	case HealthSuspended:
+		return 2
+	case HealthUnhealthy:
+		return 4
+	case HealthNotFound:
+		return 5
+	}
+	return 3
 }
 
 type ClusterCreationParams struct {
@@ -79,14 +177,15 @@ type Generation struct {
 }
 
 type ClusterPlantType struct {
-	Id   string `json:"uuid"`
-	Name string `json:"name"`
-	K8sContext K8sContext `json:"k8sContext"`
+	Id          string     `json:"uuid"`
+	Name        string     `json:"name"`
+	Description string     `json:"description,omitempty"`
+	K8sContext  K8sContext `json:"k8sContext"`
 }
 
 type Region struct {
-	Id     string `json:"uuid"`
-	Name   string `json:"name"`
+	Id   string `json:"uuid"`
+	Name string `json:"name"`
 }
 
 type ClusterCreatedResponse struct {
@@ -94,14 +193,45 @@ type ClusterCreatedResponse struct {
 }
 
 type Cluster struct {
-	ID              string          `json:"uuid"`
-	Name            string          `json:"name"`
-	Channel         Channel         `json:"channel"`
-	Generation      Generation      `json:"generation"`
-	Created         string          `json:"created"`
-	//K8sContext      K8sContext      `json:"k8sContext"`
-	ClusterMetadata ClusterMetadata `json:"metadata"`
+	ID               string           `json:"uuid"`
+	Name             string           `json:"name"`
+	Channel          Channel          `json:"channel"`
+	Generation       Generation       `json:"generation"`
+	Created          time.Time        `json:"created"`
+	K8sContext       K8sContext       `json:"k8sContext"`
+	ClusterMetadata  ClusterMetadata  `json:"metadata"`
 	ClusterPlantType ClusterPlantType `json:"planType"`
+	Status           ClusterStatus    `json:"status"`
+	Links            ClusterLinks     `json:"links"`
+	// IPAllowlist restricts the IPs allowed to reach the cluster, empty when any is.
+	IPAllowlist []IPAllowlistEntry `json:"ipWhiteList,omitempty"`
+}
+
+// UnmarshalJSON decodes the creation time leniently, see lenientTime.
+func (c *Cluster) UnmarshalJSON(data []byte) error {
+	type plain Cluster
+	decoded := struct {
+		*plain
+		Created lenientTime `json:"created"`
+	}{plain: (*plain)(c)}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	c.Created = time.Time(decoded.Created)
+	return nil
+}
+
+// Health aggregates the health of the cluster and its components.
+func (c Cluster) Health() Health {
+	return c.Status.Health()
+}
+
+// ClusterLinks are the endpoints of the components running in a cluster.
+type ClusterLinks struct {
+	Zeebe    string `json:"zeebe"`
+	Operate  string `json:"operate"`
+	Tasklist string `json:"tasklist"`
+	Optimize string `json:"optimize,omitempty"`
 }
 
 type K8sContext struct {
@@ -112,22 +242,79 @@ type K8sContext struct {
 }
 
 type ClusterMetadata struct {
-	ID                string `json:"uid"`
-	CreationTimestamp string `json:"creationTimestamp"`
-	Generation        int    `json:"generation"`
-	Name              string `json:"name"`
-	ResourceVersion   string `json:"resourceVersion"`
-	SelfLink          string `json:"selfLink"`
+	ID                string    `json:"uid"`
+	CreationTimestamp time.Time `json:"creationTimestamp"`
+	Generation        int       `json:"generation"`
+	Name              string    `json:"name"`
+	ResourceVersion   string    `json:"resourceVersion"`
+	SelfLink          string    `json:"selfLink"`
+}
+
+// UnmarshalJSON decodes the creation time leniently, see lenientTime.
+func (m *ClusterMetadata) UnmarshalJSON(data []byte) error {
+	type plain ClusterMetadata
+	decoded := struct {
+		*plain
+		CreationTimestamp lenientTime `json:"creationTimestamp"`
+	}{plain: (*plain)(m)}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	m.CreationTimestamp = time.Time(decoded.CreationTimestamp)
+	return nil
 }
 
 type ZeebeClientResponse struct {
-	ClientID    string   `json:"clientId"`
-	Created     string   `json:"created"`
-	CreatedBy   string   `json:"createdBy"`
-	UUID        string   `json:"uuid"`
-	Name        string   `json:"name"`
-	Internal    bool     `json:"internal"`
-	Permissions []string `json:"permissions"`
+	ClientID    string             `json:"clientId"`
+	Created     time.Time          `json:"created"`
+	CreatedBy   string             `json:"createdBy"`
+	UUID        string             `json:"uuid"`
+	Name        string             `json:"name"`
+	Internal    bool               `json:"internal"`
+	Permissions []ZeebeClientScope `json:"permissions"`
+}
+
+// UnmarshalJSON decodes the creation time leniently, see lenientTime.
+func (r *ZeebeClientResponse) UnmarshalJSON(data []byte) error {
+	type plain ZeebeClientResponse
+	decoded := struct {
+		*plain
+		Created lenientTime `json:"created"`
+	}{plain: (*plain)(r)}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	r.Created = time.Time(decoded.Created)
+	return nil
+}
+
+// lenientTime is a timestamp of the API that decodes to the zero time when it is null, empty or
+// in an unknown format, instead of failing the whole response. RFC3339 is expected, RFC3339
+// without time zone, taken as UTC, and Unix milliseconds are accepted as well.
+type lenientTime time.Time
+
+var lenientTimeLayouts = []string{time.RFC3339Nano, "2006-01-02T15:04:05.999999999", "2006-01-02 15:04:05.999999999"}
+
+func (t *lenientTime) UnmarshalJSON(data []byte) error {
+	*t = lenientTime{}
+	var millis int64
+	if err := json.Unmarshal(data, &millis); err == nil {
+		if millis > 0 {
+			*t = lenientTime(time.Unix(0, millis*int64(time.Millisecond)).UTC())
+		}
+		return nil
+	}
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil
+	}
+	for _, layout := range lenientTimeLayouts {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			*t = lenientTime(parsed)
+			return nil
+		}
+	}
+	return nil
 }
 
 type ZeebeClientDetailsResponse struct {
@@ -144,5 +331,152 @@ type ZeebeClientCreatedResponse struct {
 }
 
 type ZeebeClientCreatePayload struct {
-	ClientName string `json:"clientName"`
+	ClientName  string             `json:"clientName"`
+	Permissions []ZeebeClientScope `json:"permissions,omitempty"`
+}
+
+type ZeebeClientUpdatePayload struct {
+	Permissions []ZeebeClientScope `json:"permissions"`
+}
+
+// ZeebeClientScope is a component a Zeebe client is allowed to access.
+// When no scopes are sent on creation, Camunda Cloud grants its default set.
+type ZeebeClientScope string
+
+const (
+	ZeebeClientScopeZeebe    ZeebeClientScope = "Zeebe"
+	ZeebeClientScopeOperate  ZeebeClientScope = "Operate"
+	ZeebeClientScopeTasklist ZeebeClientScope = "Tasklist"
+	ZeebeClientScopeOptimize ZeebeClientScope = "Optimize"
+	ZeebeClientScopeSecrets  ZeebeClientScope = "Secrets"
+)
+
+// ZeebeClientScopes lists every scope known to this client.
+var ZeebeClientScopes = []ZeebeClientScope{
+	ZeebeClientScopeZeebe,
+	ZeebeClientScopeOperate,
+	ZeebeClientScopeTasklist,
+	ZeebeClientScopeOptimize,
+	ZeebeClientScopeSecrets,
+}
+
+// ParseZeebeClientScopes converts scope names (case insensitive) into typed scopes.
+func ParseZeebeClientScopes(names []string) ([]ZeebeClientScope, error) {
+	scopes := []ZeebeClientScope{}
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		scope, ok := findZeebeClientScope(name)
+		if !ok {
+			return nil, NewError("Unknown zeebe client scope: " + name)
+		}
+		scopes = append(scopes, scope)
+	}
+	return scopes, nil
+}
+
+func findZeebeClientScope(name string) (ZeebeClientScope, bool) {
+	for _, scope := range ZeebeClientScopes {
+		if strings.EqualFold(string(scope), name) {
+			return scope, true
+		}
+	}
+	return "", false
+}
+
+// MemberRole is a role a member holds in the Camunda Cloud organization.
+type MemberRole string
+
+const (
+	MemberRoleOwner              MemberRole = "owner"
+	MemberRoleAdmin              MemberRole = "admin"
+	MemberRoleOperationsEngineer MemberRole = "operationsengineer"
+	MemberRoleDeveloper          MemberRole = "developer"
+	MemberRoleAnalyst            MemberRole = "analyst"
+	MemberRoleSupportAgent       MemberRole = "supportagent"
+	MemberRoleVisitor            MemberRole = "visitor"
+)
+
+// MemberRoles lists every organization role known to this client.
+var MemberRoles = []MemberRole{
+	MemberRoleOwner,
+	MemberRoleAdmin,
+	MemberRoleOperationsEngineer,
+	MemberRoleDeveloper,
+	MemberRoleAnalyst,
+	MemberRoleSupportAgent,
+	MemberRoleVisitor,
+}
+
+// ParseMemberRoles converts role names (case insensitive) into typed roles.
+func ParseMemberRoles(names []string) ([]MemberRole, error) {
+	roles := []MemberRole{}
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		role, ok := findMemberRole(name)
+		if !ok {
+			return nil, NewError("Unknown member role: " + name)
+		}
+		roles = append(roles, role)
+	}
+	return roles, nil
+}
+
+func findMemberRole(name string) (MemberRole, bool) {
+	for _, role := range MemberRoles {
+		if strings.EqualFold(string(role), name) {
+			return role, true
+		}
+	}
+	return "", false
+}
+
+// IPAllowlistEntry is an IP, or a CIDR range, allowed to reach a cluster.
+type IPAllowlistEntry struct {
+	Description string `json:"description"`
+	IP          string `json:"ip"`
+}
+
+type IPAllowlistPayload struct {
+	IPAllowlist []IPAllowlistEntry `json:"ipwhitelist"`
+}
+
+type UpgradeClusterPayload struct {
+	GenerationID string `json:"generationId"`
+}
+
+type ConnectorSecretPayload struct {
+	SecretName  string `json:"secretName"`
+	SecretValue string `json:"secretValue"`
+}
+
+type OrganizationMember struct {
+	Name          string       `json:"name"`
+	Email         string       `json:"email"`
+	Roles         []MemberRole `json:"roles"`
+	InvitePending bool         `json:"invitePending"`
+}
+
+type MemberRolesPayload struct {
+	OrgRoles []MemberRole `json:"orgRoles"`
+}
+
+func (c *Cluster) fillLinksFromStatus() {
+	if c.Links.Zeebe == "" {
+		c.Links.Zeebe = c.Status.ZeebeURL
+	}
+	if c.Links.Operate == "" {
+		c.Links.Operate = c.Status.OperateURL
+	}
+	if c.Links.Tasklist == "" {
+		c.Links.Tasklist = c.Status.TaskListURL
+	}
+	if c.Links.Optimize == "" {
+		c.Links.Optimize = c.Status.OptimizeURL
+	}
 }
@@ -0,0 +1,121 @@
+package client
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Cluster_decodesTypedFields(t *testing.T) {
+	body := `{
+		"uuid": "1",
+		"name": "prod-eu",
+		"created": "2021-03-05T10:13:59.012Z",
+		"metadata": {"creationTimestamp": "2021-03-05T10:14:00Z"},
+		"status": {"ready": "Healthy", "zeebeStatus": "Healthy", "operateStatus": "Rebalancing", "tasklistStatus": "Healthy"}
+	}`
+
+	cluster := Cluster{}
+	assert.NoError(t, json.Unmarshal([]byte(body), &cluster))
+
+	assert.Equal(t, time.Date(2021, 3, 5, 10, 13, 59, 12000000, time.UTC), cluster.Created)
+	assert.Equal(t, time.Date(2021, 3, 5, 10, 14, 0, 0, time.UTC), cluster.ClusterMetadata.CreationTimestamp)
+	assert.Equal(t, Health("Rebalancing"), cluster.Status.OperateStatus)
+	assert.False(t, cluster.Status.OperateStatus.IsKnown())
+}
+
+func Test_ClusterStatus_health(t *testing.T) {
+	healthy := ClusterStatus{Ready: HealthHealthy, ZeebeStatus: HealthHealthy, OperateStatus: HealthHealthy}
+	assert.True(t, healthy.IsReady())
+	assert.False(t, healthy.IsDegraded())
+	assert.Equal(t, HealthHealthy, healthy.Health())
+	assert.Equal(t, []ComponentHealth{{"zeebe", HealthHealthy}, {"operate", HealthHealthy}}, healthy.Components())
+
+	creating := ClusterStatus{Ready: HealthCreating, ZeebeStatus: HealthCreating}
+	assert.False(t, creating.IsReady())
+	assert.False(t, creating.IsDegraded())
+	assert.Equal(t, HealthCreating, creating.Health())
+
+	degraded := ClusterStatus{Ready: HealthHealthy, ZeebeStatus: HealthHealthy, TaskListStatus: HealthUnhealthy}
+	assert.True(t, degraded.IsDegraded())
+	assert.Equal(t, HealthUnhealthy, degraded.Health())
+
+	unknown := ClusterStatus{Ready: HealthHealthy, ZeebeStatus: "Rebalancing"}
+	assert.True(t, unknown.IsDegraded())
+	assert.Equal(t, Health("Rebalancing"), unknown.Health())
+}
+
+func Test_ClusterStatus_emptyIsNotDegraded(t *testing.T) {
+	empty := ClusterStatus{}
+	assert.False(t, empty.IsDegraded())
+	assert.False(t, empty.IsReady())
+	assert.Equal(t, Health(""), empty.Health())
+}
+
+func Test_Cluster_decodesTimestampsLeniently(t *testing.T) {
+	tests := []struct {
+		created string
+		want    time.Time
+	}{
+		{`"2021-03-05T10:13:59+01:00"`, time.Date(2021, 3, 5, 9, 13, 59, 0, time.UTC)},
+		{`"2021-03-05T10:13:59.5"`, time.Date(2021, 3, 5, 10, 13, 59, 500000000, time.UTC)},
+		{`"2021-03-05 10:13:59"`, time.Date(2021, 3, 5, 10, 13, 59, 0, time.UTC)},
+		{`1614939239000`, time.Date(2021, 3, 5, 10, 13, 59, 0, time.UTC)},
+		{`""`, time.Time{}},
+		{`null`, time.Time{}},
+		{`"yesterday"`, time.Time{}},
+		{`{"seconds": 1}`, time.Time{}},
+	}
+	for _, test := range tests {
+		t.Run(test.created, func(t *testing.T) {
+			body := `[{"uuid": "1", "name": "prod-eu", "created": ` + test.created + `,
+				"metadata": {"creationTimestamp": ` + test.created + `}}]`
+
+			clusters := []Cluster{}
+			assert.NoError(t, json.Unmarshal([]byte(body), &clusters))
+
+			assert.Len(t, clusters, 1)
+			assert.Equal(t, "prod-eu", clusters[0].Name)
+			assert.True(t, test.want.Equal(clusters[0].Created), "created %v", clusters[0].Created)
+			assert.True(t, test.want.Equal(clusters[0].ClusterMetadata.CreationTimestamp))
+		})
+	}
+}
+
+func Test_ZeebeClientResponse_decodesTimestampsLeniently(t *testing.T) {
+	body := `[{"clientId": "a", "name": "worker", "created": "2021-03-05T10:13:59Z"}, {"clientId": "b", "created": ""}]`
+
+	clients := []ZeebeClientResponse{}
+	assert.NoError(t, json.Unmarshal([]byte(body), &clients))
+
+	assert.Len(t, clients, 2)
+	assert.Equal(t, "worker", clients[0].Name)
+	assert.Equal(t, time.Date(2021, 3, 5, 10, 13, 59, 0, time.UTC), clients[0].Created)
+	assert.Equal(t, "b", clients[1].ClientID)
+	assert.True(t, clients[1].Created.IsZero())
+}
+
+func Test_Cluster_encodesTimestamps(t *testing.T) {
+	cluster := Cluster{ID: "1", Created: time.Date(2021, 3, 5, 10, 13, 59, 0, time.UTC)}
+
+	encoded, err := json.Marshal(cluster)
+	assert.NoError(t, err)
+
+	decoded := Cluster{}
+	assert.NoError(t, json.Unmarshal(encoded, &decoded))
+	assert.Equal(t, cluster.Created, decoded.Created)
+	assert.Equal(t, "1", decoded.ID)
+}
+
+func Test_Cluster_fillLinksFromStatus(t *testing.T) {
+	cluster := Cluster{
+		Links:  ClusterLinks{Zeebe: "zeebe-link"},
+		Status: ClusterStatus{ZeebeURL: "zeebe-status", OperateURL: "operate-status", TaskListURL: "tasklist-status"},
+	}
+
+	cluster.fillLinksFromStatus()
+
+	assert.Equal(t, ClusterLinks{Zeebe: "zeebe-link", Operate: "operate-status", Tasklist: "tasklist-status"}, cluster.Links)
+}
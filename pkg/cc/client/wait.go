@@ -0,0 +1,59 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+)
+
+// DefaultPollInterval is the time between two status requests of WaitForClusterHealthy.
+const DefaultPollInterval = 10 * time.Second
+
+// ErrClusterNotFound is returned when a cluster waited for does not exist, or does not anymore.
+var ErrClusterNotFound = errors.New("cluster not found")
+
+// WaitForClusterHealthy polls the cluster details every interval, DefaultPollInterval when zero,
+// until Zeebe is healthy and returns the last status. Transport errors, 429 and 5xx answers are
+// polled through, other errors are returned. It gives up when the context is done, returning
+// the last status received and the error of the context.
+func (c *CCClient) WaitForClusterHealthy(ctx context.Context, clusterID string, interval time.Duration) (ClusterStatus, error) {
+
+	if c.tracingEnabled {
+		_, span := c.tracer.Start(ctx, "waitForClusterHealthy")
+		defer span.End()
+	}
+
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+
+	var status ClusterStatus
+	for {
+		current, err := c.GetClusterDetailsWithContext(ctx, clusterID)
+		switch {
+		case ctx.Err() != nil:
+			return status, ctx.Err()
+		case err != nil && !isTransient(err):
+			return current, err
+		case err != nil:
+			log.Printf("failed to get the status of cluster %s, polling again: %v", clusterID, err)
+		case current.Ready == HealthNotFound:
+			return current, fmt.Errorf("%w: %s", ErrClusterNotFound, clusterID)
+		default:
+			status = current
+			if status.ZeebeStatus == HealthHealthy || (status.ZeebeStatus == "" && status.IsReady()) {
+				return status, nil
+			}
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return status, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/camunda-community-hub/camunda-cloud-go-client/pkg/cc/cctest"
+	cc "github.com/camunda-community-hub/camunda-cloud-go-client/pkg/cc/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_WaitForClusterHealthy(t *testing.T) {
+	srv := cctest.NewServer()
+	defer srv.Close()
+	srv.CreatingPolls = 3
+	ccClient := srv.Client()
+	clusterID, err := ccClient.CreateClusterDefault("orders")
+	assert.NoError(t, err)
+
+	status, err := ccClient.WaitForClusterHealthy(context.Background(), clusterID, time.Millisecond)
+
+	assert.NoError(t, err)
+	assert.Equal(t, cc.HealthHealthy, status.ZeebeStatus)
+	assert.Len(t, srv.RequestsTo("GET /clusters/{clusterId}"), 4)
+
+	_, err = ccClient.WaitForClusterHealthy(context.Background(), "missing", time.Millisecond)
+	assert.True(t, errors.Is(err, cc.ErrClusterNotFound))
+}
+
+func Test_WaitForClusterHealthy_contextDone(t *testing.T) {
+	srv := cctest.NewServer()
+	defer srv.Close()
+	srv.CreatingPolls = 1000
+	ccClient := srv.Client()
+	clusterID, _ := ccClient.CreateClusterDefault("orders")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	status, err := ccClient.WaitForClusterHealthy(ctx, clusterID, time.Millisecond)
+
+	assert.Equal(t, context.DeadlineExceeded, err)
+	assert.Equal(t, cc.HealthCreating, status.Ready)
+}
+
+func Test_WaitForClusterHealthy_transientErrors(t *testing.T) {
+	srv := cctest.NewServer()
+	defer srv.Close()
+	srv.CreatingPolls = 2
+	ccClient := srv.Client()
+	clusterID, _ := ccClient.CreateClusterDefault("orders")
+	srv.InjectFault("GET /clusters/{clusterId}", cctest.Fail(503, 1))
+	srv.InjectFault("GET /clusters/{clusterId}", cctest.RateLimit(time.Second, 1))
+
+	status, err := ccClient.WaitForClusterHealthy(context.Background(), clusterID, time.Millisecond)
+
+	assert.NoError(t, err)
+	assert.Equal(t, cc.HealthHealthy, status.ZeebeStatus)
+	assert.Len(t, srv.RequestsTo("GET /clusters/{clusterId}"), 5, "2 failures, then 3 polls")
+}
+
+func Test_WaitForClusterHealthy_errors(t *testing.T) {
+	srv := cctest.NewServer()
+	defer srv.Close()
+	ccClient := srv.Client()
+	clusterID, _ := ccClient.CreateClusterDefault("orders")
+
+	srv.InjectFault("GET /clusters/{clusterId}", cctest.Fail(401, 1))
+	_, err := ccClient.WaitForClusterHealthy(context.Background(), clusterID, time.Millisecond)
+	assert.EqualError(t, err, "HTTP Error trying to getClusterDetails: 401")
+
+	srv.InjectFault("GET /clusters/{clusterId}", cctest.Fault{MalformedJSON: true, Times: 1})
+	_, err = ccClient.WaitForClusterHealthy(context.Background(), clusterID, time.Millisecond)
+	assert.Error(t, err)
+	assert.False(t, errors.Is(err, cc.ErrClusterNotFound))
+}
@@ -0,0 +1,269 @@
+// Package recorder records the HTTP traffic of the client to YAML cassettes and replays
+// it, so that tests run against recorded payloads without an account or network access.
+// Credentials, tokens and secrets are scrubbed and the host of the server is normalized
+// before a cassette is written.
+//
+//	rec, err := recorder.New("testdata/cassettes/clusters.yaml", recorder.ModeReplay, nil)
+//	defer rec.Stop()
+//
+//	ccClient := &cc.CCClient{}
+//	ccClient.SetHTTPClient(rec.Client())
+package recorder
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Mode tells whether a Recorder records or replays.
+type Mode int
+
+const (
+	// ModeReplay answers requests from the cassette, without any network access.
+	ModeReplay Mode = iota
+	// ModeRecord sends requests on with the transport and writes them to the cassette on Stop.
+	ModeRecord
+)
+
+// Redacted replaces the scrubbed values.
+const Redacted = "REDACTED"
+
+// RecordedHost replaces, in the recorded response bodies, the scheme and host the requests were
+// sent to, so that cassettes recorded against a local server do not depend on its port.
+const RecordedHost = "http://recorded.invalid"
+
+// ScrubbedKeys are the JSON keys whose values never make it into a cassette.
+var ScrubbedKeys = []string{
+	"access_token",
+	"refresh_token",
+	"id_token",
+	"client_id",
+	"client_secret",
+	"clientSecret",
+	"ZEEBE_CLIENT_SECRET",
+}
+
+// Cassette is the recorded traffic, in the order it happened.
+type Cassette struct {
+	Interactions []Interaction `yaml:"interactions"`
+}
+
+// Interaction is a request and the response it got.
+type Interaction struct {
+	Request  Request  `yaml:"request"`
+	Response Response `yaml:"response"`
+}
+
+// Request is a recorded request. URL only holds the path and query, so that a cassette
+// recorded against one server replays against any base URL.
+type Request struct {
+	Method string `yaml:"method"`
+	URL    string `yaml:"url"`
+	Body   string `yaml:"body,omitempty"`
+}
+
+// Response is a recorded response.
+type Response struct {
+	Status      int    `yaml:"status"`
+	ContentType string `yaml:"contentType,omitempty"`
+	Body        string `yaml:"body,omitempty"`
+}
+
+// Recorder is an http.RoundTripper that records to, or replays from, a cassette.
+type Recorder struct {
+	path      string
+	mode      Mode
+	transport http.RoundTripper
+
+	mu       sync.Mutex
+	cassette Cassette
+	used     []bool
+}
+
+// New returns a recorder for the cassette at path. In ModeRecord requests are sent with
+// transport, http.DefaultTransport when nil. In ModeReplay the cassette must exist.
+func New(path string, mode Mode, transport http.RoundTripper) (*Recorder, error) {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	r := &Recorder{path: path, mode: mode, transport: transport}
+
+	if mode == ModeReplay {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if err := yaml.UnmarshalStrict(data, &r.cassette); err != nil {
+			return nil, fmt.Errorf("invalid cassette %s: %v", path, err)
+		}
+		r.used = make([]bool, len(r.cassette.Interactions))
+	}
+	return r, nil
+}
+
+// Client returns an HTTP client going through the recorder.
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// Interactions returns the interactions recorded or replayed so far.
+func (r *Recorder) Interactions() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.mode == ModeRecord {
+		return append([]Interaction{}, r.cassette.Interactions...)
+	}
+	replayed := []Interaction{}
+	for i, interaction := range r.cassette.Interactions {
+		if r.used[i] {
+			replayed = append(replayed, interaction)
+		}
+	}
+	return replayed
+}
+
+// Stop writes the cassette in ModeRecord. It does nothing in ModeReplay.
+func (r *Recorder) Stop() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	data, err := yaml.Marshal(r.cassette)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(r.path, data, 0644)
+}
+
+// RoundTrip records or replays a request.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		if body, err = ioutil.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		req.Body.Close()
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+
+	recorded := Request{Method: req.Method, URL: req.URL.RequestURI(), Body: scrub(body)}
+
+	if r.mode == ModeReplay {
+		return r.replay(req, recorded)
+	}
+	return r.record(req, recorded)
+}
+
+func (r *Recorder) record(req *http.Request, recorded Request) (*http.Response, error) {
+	resp, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	recordedBody := bytes.ReplaceAll(body, []byte(req.URL.Scheme+"://"+req.URL.Host), []byte(RecordedHost))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request: recorded,
+		Response: Response{
+			Status:      resp.StatusCode,
+			ContentType: resp.Header.Get("Content-Type"),
+			Body:        scrub(recordedBody),
+		},
+	})
+	return resp, nil
+}
+
+// replay answers with the first interaction of the cassette with the same method and URL
+// that was not replayed yet, so that repeated requests get the responses in recorded order.
+func (r *Recorder) replay(req *http.Request, recorded Request) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, interaction := range r.cassette.Interactions {
+		if r.used[i] || interaction.Request.Method != recorded.Method || interaction.Request.URL != recorded.URL {
+			continue
+		}
+		r.used[i] = true
+
+		header := http.Header{}
+		if interaction.Response.ContentType != "" {
+			header.Set("Content-Type", interaction.Response.ContentType)
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.Status, http.StatusText(interaction.Response.Status)),
+			StatusCode:    interaction.Response.Status,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          ioutil.NopCloser(bytes.NewReader([]byte(interaction.Response.Body))),
+			ContentLength: int64(len(interaction.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("recorder: no interaction left in %s for %s %s", r.path, recorded.Method, recorded.URL)
+}
+
+// scrub replaces the values of the ScrubbedKeys of a JSON body. Other bodies are kept as they are.
+func scrub(body []byte) string {
+	var v interface{}
+	if len(body) == 0 || json.Unmarshal(body, &v) != nil {
+		return string(body)
+	}
+	scrubbed, err := json.Marshal(scrubValue(v))
+	if err != nil {
+		return string(body)
+	}
+	return string(scrubbed)
+}
+
+func scrubValue(v interface{}) interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		for key, field := range value {
+			if isScrubbedKey(key) {
+				if _, isString := field.(string); isString {
+					value[key] = Redacted
+					continue
+				}
+			}
+			value[key] = scrubValue(field)
+		}
+	case []interface{}:
+		for i, item := range value {
+			value[i] = scrubValue(item)
+		}
+	}
+	return v
+}
+
+func isScrubbedKey(key string) bool {
+	for _, scrubbed := range ScrubbedKeys {
+		if key == scrubbed {
+			return true
+		}
+	}
+	return false
+}
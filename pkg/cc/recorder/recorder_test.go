@@ -0,0 +1,68 @@
+package recorder
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/camunda-community-hub/camunda-cloud-go-client/pkg/cc/cctest"
+	cc "github.com/camunda-community-hub/camunda-cloud-go-client/pkg/cc/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Recorder_recordAndReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassette.yaml")
+	srv := cctest.NewServer()
+	defer srv.Close()
+	cluster := srv.AddCluster(cc.Cluster{Name: "orders"})
+
+	rec, err := New(path, ModeRecord, nil)
+	require.NoError(t, err)
+	recording := &cc.CCClient{}
+	srv.Configure(recording)
+	recording.SetHTTPClient(rec.Client())
+	_, err = recording.Login("id", "secret")
+	require.NoError(t, err)
+	created, err := recording.CreateZeebeClient(cluster.ID, "worker")
+	require.NoError(t, err)
+	_, err = recording.GetZeebeClientDetails(cluster.ID, created.ClientID)
+	require.NoError(t, err)
+	require.NoError(t, rec.Stop())
+
+	data, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	for _, secret := range []string{"secret", recording.AuthResponsePayload.AccessToken, created.ClientSecret} {
+		assert.False(t, strings.Contains(string(data), `"`+secret+`"`), "%s is not scrubbed", secret)
+	}
+	assert.NotContains(t, string(data), srv.URL, "the host of the server is normalized")
+	srv.Close()
+
+	rec, err = New(path, ModeReplay, nil)
+	require.NoError(t, err)
+	replaying := &cc.CCClient{}
+	replaying.SetAPIURL("http://replayed")
+	replaying.SetLoginURL("http://replayed")
+	replaying.SetHTTPClient(rec.Client())
+
+	_, err = replaying.Login("id", "secret")
+	assert.NoError(t, err)
+	assert.Equal(t, Redacted, replaying.AuthResponsePayload.AccessToken)
+	replayed, err := replaying.CreateZeebeClient(cluster.ID, "worker")
+	assert.NoError(t, err)
+	assert.Equal(t, cc.ZeebeClientCreatedResponse{Name: "worker", ClientID: created.ClientID, ClientSecret: Redacted}, replayed)
+	details, err := replaying.GetZeebeClientDetails(cluster.ID, created.ClientID)
+	assert.NoError(t, err)
+	assert.Equal(t, RecordedHost+"/oauth/token", details.ZEEBEAUTHORIZATIONSERVERURL)
+	assert.Len(t, rec.Interactions(), 3)
+
+	_, err = replaying.CreateZeebeClient(cluster.ID, "worker")
+	assert.Error(t, err, "every interaction is replayed once")
+}
+
+func Test_scrub(t *testing.T) {
+	assert.Equal(t, `{"clients":[{"clientSecret":"REDACTED","name":"worker"}],"expires_in":3600}`,
+		scrub([]byte(`{"clients":[{"name":"worker","clientSecret":"s3cr3t"}],"expires_in":3600}`)))
+	assert.Equal(t, "not json", scrub([]byte("not json")))
+}